@@ -0,0 +1,131 @@
+package goarchtest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OnlyBeAccessedBy checks that the packages of the selected types are only imported
+// by packages residing in one of the given namespaces.
+// It is a condition rather than a filter: every selected type must satisfy it,
+// and each unauthorized importer is reported as a separate Violation.
+// Namespaces are patterns understood by NamespaceMatcher.
+//
+// Parameters:
+//   - namespaces: The namespaces allowed to import the selected packages
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet containing only types whose packages have no unauthorized importers,
+//     allowing for method chaining
+//
+// Example:
+//
+//	result := types.That().
+//	    ResideInNamespace("infrastructure/db").
+//	    Should().
+//	    OnlyBeAccessedBy("cmd", "infrastructure").
+//	    GetResult()
+func (ts *TypeSet) OnlyBeAccessedBy(namespaces ...string) *TypeSet {
+	ts.currentPredicate = "OnlyBeAccessedBy"
+
+	matches := ts.pathMatcher(namespaces...)
+
+	var filteredTypes []*TypeInfo
+	for _, t := range ts.types {
+		authorized := true
+		for _, importer := range ts.importersOf(t) {
+			if matches(importer) {
+				continue
+			}
+
+			authorized = false
+			ts.recordMiss(Violation{
+				Type:     t,
+				Reason:   fmt.Sprintf("%s is imported by %s, which is not in %s", t.FullPath, importer, strings.Join(namespaces, ", ")),
+				Evidence: importer,
+				Position: ts.importPosition(importer, t.FullPath),
+			})
+		}
+
+		if authorized {
+			filteredTypes = append(filteredTypes, t)
+		}
+	}
+
+	ts.types = filteredTypes
+	ts.strict = true
+	ts.describe("be accessed only by " + quoteAll(namespaces))
+	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
+	return ts
+}
+
+// BeAccessedBy filters types whose packages are imported by at least one package
+// residing in one of the given namespaces.
+// Combined with ShouldNot it forbids access, reporting each offending importer as a Violation.
+//
+// Parameters:
+//   - namespaces: The namespaces of the importers to look for
+//
+// Returns:
+//   - *TypeSet: Returns the filtered TypeSet containing only types imported from the namespaces,
+//     allowing for method chaining
+//
+// Example:
+//
+//	result := types.That().
+//	    ResideInNamespace("infrastructure/db").
+//	    ShouldNot().
+//	    BeAccessedBy("presentation").
+//	    GetResult()
+func (ts *TypeSet) BeAccessedBy(namespaces ...string) *TypeSet {
+	ts.currentPredicate = "BeAccessedBy"
+
+	matches := ts.pathMatcher(namespaces...)
+
+	var filteredTypes []*TypeInfo
+	for _, t := range ts.types {
+		accessed := false
+		for _, importer := range ts.importersOf(t) {
+			if !matches(importer) {
+				continue
+			}
+
+			accessed = true
+			ts.recordMatch(Violation{
+				Type:     t,
+				Reason:   fmt.Sprintf("%s is imported by %s", t.FullPath, importer),
+				Evidence: importer,
+				Position: ts.importPosition(importer, t.FullPath),
+			})
+		}
+
+		if accessed {
+			filteredTypes = append(filteredTypes, t)
+		}
+	}
+
+	ts.types = filteredTypes
+	ts.describe("be accessed by " + quoteAll(namespaces))
+	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
+	return ts
+}
+
+// importersOf returns the packages importing the package of the given type
+func (ts *TypeSet) importersOf(t *TypeInfo) []string {
+	if ts.model == nil {
+		return nil
+	}
+	return ts.model.importers[t.FullPath]
+}
+
+// importPosition returns where the importer package imports the given path
+func (ts *TypeSet) importPosition(importer, importPath string) Position {
+	if ts.model == nil || ts.model.index == nil {
+		return Position{}
+	}
+	// The types of a package share the positions of its imports
+	if types := ts.model.index.typesByPackage[importer]; len(types) > 0 {
+		return types[0].ImportPosition(importPath)
+	}
+	return Position{}
+}
@@ -2,47 +2,242 @@ package goarchtest
 
 import (
 	"fmt"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
-// Rule represents an architectural rule with a description and validation function
+// Rule represents an architectural rule with a description and validation function.
+//
+// Fields:
+//   - ID: A stable identifier, e.g. "clean-architecture/domain-not-depend-on-application".
+//     When empty, one is derived from the pattern name and the description, or
+//     from the generated description once the rule is validated.
+//   - Description: A human-readable statement of the rule; when empty, the
+//     description generated from the rule's fluent chain is used
+//   - Severity: How serious a violation is; the zero value means SeverityError
+//   - Tags: Free-form labels for filtering and grouping
+//   - Rationale: Why the rule exists
+//   - DocLinks: URLs with further documentation
+//   - Validate: The function that evaluates the rule
 type Rule struct {
+	ID          string
 	Description string
+	Severity    Severity
+	Tags        []string
+	Rationale   string
+	DocLinks    []string
 	Validate    func(*Types) *Result
+
+	// pattern names the pattern the rule was merged from, which prefixes its
+	// derived ID. For rules whose ID is generated, excluded and overrides hold
+	// the IDs passed to Without and Override while the rule was part of a
+	// pattern, applied once the ID is known.
+	pattern   string
+	excluded  []string
+	overrides []severityOverride
+}
+
+// severityOverride is a severity set with Override for a rule ID
+type severityOverride struct {
+	ruleID   string
+	severity Severity
+}
+
+// hasGeneratedID reports whether the ID of the rule is only known once it is
+// validated, from the description generated by its chain
+func (rule Rule) hasGeneratedID() bool {
+	return rule.ID == "" && rule.Description == ""
 }
 
-// ArchitecturePattern represents a predefined architectural pattern
+// ArchitecturePattern represents a predefined architectural pattern.
+// Patterns are composed with With, Without, Merge, Override and Nest; nested
+// Patterns are validated after the pattern's own rules.
 type ArchitecturePattern struct {
-	Name  string
-	Rules []Rule
+	Name     string
+	Rules    []Rule
+	Patterns []*ArchitecturePattern
+
+	// Parallelism is how many rules Validate evaluates concurrently, including
+	// the rules of nested patterns. Zero uses GOMAXPROCS; 1 evaluates rules sequentially.
+	Parallelism int
 }
 
-// Validate checks if the codebase adheres to the architectural pattern
+// Validate checks if the codebase adheres to the architectural pattern.
+//
+// Rules are evaluated concurrently by a pool of workers, GOMAXPROCS by default;
+// set Parallelism to change it. Results are returned in
+// the order of the rules, followed by the results of nested patterns, regardless
+// of the order in which rules complete.
 func (ap *ArchitecturePattern) Validate(types *Types) []*ValidationResult {
-	var results []*ValidationResult
+	var jobs []ruleJob
+	ap.collectJobs(nil, &jobs)
+
+	results := make([]*ValidationResult, len(jobs))
+	workers := min(ap.workers(), len(jobs))
+
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = jobs[i].run(types)
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	// Rules removed by their generated ID are only known once evaluated
+	return slices.DeleteFunc(results, func(result *ValidationResult) bool {
+		return result == nil
+	})
+}
+
+// ruleJob is a rule to evaluate, together with the pattern that defines it
+type ruleJob struct {
+	pattern     *ArchitecturePattern
+	patternPath []string
+	index       int
+	rule        Rule
+}
+
+// collectJobs lists the pattern's rules and then those of its nested patterns
+func (ap *ArchitecturePattern) collectJobs(parentPath []string, jobs *[]ruleJob) {
+	patternPath := append(slices.Clone(parentPath), ap.Name)
 
 	for i, rule := range ap.Rules {
-		result := rule.Validate(types)
-		validationResult := &ValidationResult{
-			PatternName:     ap.Name,
-			RuleIndex:       i,
-			RuleDescription: rule.Description,
-			IsSuccessful:    result.IsSuccessful,
-			FailingTypes:    result.FailingTypes,
+		*jobs = append(*jobs, ruleJob{pattern: ap, patternPath: patternPath, index: i, rule: rule})
+	}
+
+	for _, pattern := range ap.Patterns {
+		pattern.collectJobs(patternPath, jobs)
+	}
+}
+
+// workers returns the number of rules evaluated concurrently
+func (ap *ArchitecturePattern) workers() int {
+	if ap.Parallelism > 0 {
+		return ap.Parallelism
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// run evaluates the rule and records how long it took. It returns nil for
+// rules removed with Without by their generated ID.
+func (job ruleJob) run(types *Types) *ValidationResult {
+	rule := job.rule
+	generated := rule.hasGeneratedID()
+
+	start := time.Now()
+	result := rule.Validate(types)
+	duration := time.Since(start)
+
+	// Rules without a description are described by their fluent chain
+	if rule.Description == "" {
+		rule.Description = result.Description
+	}
+
+	id := job.pattern.ruleID(rule)
+	if generated {
+		if slices.Contains(rule.excluded, id) {
+			return nil
+		}
+		for _, override := range rule.overrides {
+			if override.ruleID == id {
+				rule.Severity = override.severity
+			}
 		}
-		results = append(results, validationResult)
 	}
 
-	return results
+	return &ValidationResult{
+		PatternName:     job.pattern.Name,
+		PatternPath:     job.patternPath,
+		RuleIndex:       job.index,
+		RuleID:          id,
+		RuleDescription: rule.Description,
+		Severity:        rule.Severity.orDefault(),
+		Tags:            rule.Tags,
+		Rationale:       rule.Rationale,
+		DocLinks:        rule.DocLinks,
+		IsSuccessful:    result.IsSuccessful,
+		FailingTypes:    result.FailingTypes,
+		Violations:      result.Violations,
+		Exemptions:      result.Exemptions,
+		Err:             result.Err,
+		SelectedCount:   result.SelectedCount,
+		EvaluatedCount:  result.EvaluatedCount,
+		Warnings:        result.Warnings,
+		Duration:        duration,
+	}
+}
+
+// ruleID returns the rule's ID, deriving a stable one from the pattern name
+// and the description when the rule has none. Merged rules keep the name of
+// the pattern they come from.
+func (ap *ArchitecturePattern) ruleID(rule Rule) string {
+	if rule.ID != "" {
+		return rule.ID
+	}
+	name := ap.Name
+	if rule.pattern != "" {
+		name = rule.pattern
+	}
+	return ruleID(slug(name), rule.Description)
+}
+
+// annotate adds the tags and documentation links shared by all rules of a pattern
+func annotate(rules []Rule, tags []string, docLinks ...string) []Rule {
+	for i := range rules {
+		rules[i].Tags = appendUnique(append([]string{}, tags...), rules[i].Tags...)
+		rules[i].DocLinks = appendUnique(append([]string{}, docLinks...), rules[i].DocLinks...)
+	}
+	return rules
 }
 
-// ValidationResult represents the result of validating an architectural pattern
+// appendUnique appends the values that are not yet in the slice
+func appendUnique(values []string, more ...string) []string {
+	for _, value := range more {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// ValidationResult represents the result of validating an architectural pattern.
+// It carries the identity and metadata of the rule it was produced by, so results
+// can be grouped by severity and reported without access to the pattern.
+// PatternName is the pattern that defines the rule; PatternPath lists the enclosing
+// patterns from the outermost one down to PatternName. Duration is the time the rule
+// took to evaluate.
 type ValidationResult struct {
 	PatternName     string
+	PatternPath     []string
 	RuleIndex       int
+	RuleID          string
 	RuleDescription string
+	Severity        Severity
+	Tags            []string
+	Rationale       string
+	DocLinks        []string
 	IsSuccessful    bool
 	FailingTypes    []*TypeInfo
+	Violations      []Violation
+	Exemptions      []Exemption
+	Err             error
+	SelectedCount   int
+	EvaluatedCount  int
+	Warnings        []string
+	FrozenCount     int
+	Duration        time.Duration
 }
 
 // CleanArchitecture defines the Clean Architecture pattern (also known as Onion Architecture).
@@ -53,7 +248,7 @@ type ValidationResult struct {
 //
 // Parameters:
 //   - domainNamespace: The namespace/package containing domain entities and business rules
-//   - applicationNamespace: The namespace/package containing application services and use cases  
+//   - applicationNamespace: The namespace/package containing application services and use cases
 //   - infrastructureNamespace: The namespace/package containing external concerns (database, web, etc.)
 //   - presentationNamespace: The namespace/package containing UI/API controllers and handlers
 //
@@ -64,10 +259,10 @@ type ValidationResult struct {
 //
 //	// Define Clean Architecture for your project
 //	pattern := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation")
-//	
+//
 //	// Validate against your codebase
 //	results := pattern.Validate(types)
-//	
+//
 //	// Check results
 //	for _, result := range results {
 //	    if !result.IsSuccessful {
@@ -77,15 +272,17 @@ type ValidationResult struct {
 //
 // The generated pattern validates these key constraints:
 //   - Domain layer cannot depend on application, infrastructure, or presentation layers
-//   - Application layer cannot depend on infrastructure or presentation layers  
+//   - Application layer cannot depend on infrastructure or presentation layers
 //   - Presentation layer cannot depend on infrastructure layer (should go through application)
 func CleanArchitecture(domainNamespace, applicationNamespace, infrastructureNamespace, presentationNamespace string) *ArchitecturePattern {
 	return &ArchitecturePattern{
 		Name: "Clean Architecture",
-		Rules: []Rule{
+		Rules: annotate([]Rule{
 			// Domain layer should not depend on any other layer
 			{
+				ID:          "clean-architecture/domain-not-depend-on-application",
 				Description: fmt.Sprintf("Domain layer (%s) should not depend on application layer (%s)", domainNamespace, applicationNamespace),
+				Rationale:   "The domain holds enterprise business rules and must not know how use cases orchestrate it.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(domainNamespace).
@@ -95,7 +292,9 @@ func CleanArchitecture(domainNamespace, applicationNamespace, infrastructureName
 				},
 			},
 			{
+				ID:          "clean-architecture/domain-not-depend-on-infrastructure",
 				Description: fmt.Sprintf("Domain layer (%s) should not depend on infrastructure layer (%s)", domainNamespace, infrastructureNamespace),
+				Rationale:   "The domain must stay independent of databases, frameworks and other technical details.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(domainNamespace).
@@ -105,7 +304,9 @@ func CleanArchitecture(domainNamespace, applicationNamespace, infrastructureName
 				},
 			},
 			{
+				ID:          "clean-architecture/domain-not-depend-on-presentation",
 				Description: fmt.Sprintf("Domain layer (%s) should not depend on presentation layer (%s)", domainNamespace, presentationNamespace),
+				Rationale:   "The domain must stay independent of how it is delivered to users.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(domainNamespace).
@@ -116,7 +317,9 @@ func CleanArchitecture(domainNamespace, applicationNamespace, infrastructureName
 			},
 			// Application layer should only depend on domain layer
 			{
+				ID:          "clean-architecture/application-not-depend-on-infrastructure",
 				Description: fmt.Sprintf("Application layer (%s) should not depend on infrastructure layer (%s)", applicationNamespace, infrastructureNamespace),
+				Rationale:   "Use cases should reach infrastructure through interfaces they own, not concrete implementations.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(applicationNamespace).
@@ -126,7 +329,9 @@ func CleanArchitecture(domainNamespace, applicationNamespace, infrastructureName
 				},
 			},
 			{
+				ID:          "clean-architecture/application-not-depend-on-presentation",
 				Description: fmt.Sprintf("Application layer (%s) should not depend on presentation layer (%s)", applicationNamespace, presentationNamespace),
+				Rationale:   "Use cases must not know which delivery mechanism invokes them.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(applicationNamespace).
@@ -137,7 +342,9 @@ func CleanArchitecture(domainNamespace, applicationNamespace, infrastructureName
 			},
 			// Presentation layer should not depend on infrastructure layer
 			{
+				ID:          "clean-architecture/presentation-not-depend-on-infrastructure",
 				Description: fmt.Sprintf("Presentation layer (%s) should not depend on infrastructure layer (%s)", presentationNamespace, infrastructureNamespace),
+				Rationale:   "Delivery mechanisms should go through the application layer instead of reaching into infrastructure.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(presentationNamespace).
@@ -146,7 +353,7 @@ func CleanArchitecture(domainNamespace, applicationNamespace, infrastructureName
 						GetResult()
 				},
 			},
-		},
+		}, []string{"clean-architecture", "layers"}, "https://blog.cleancoder.com/uncle-bob/2012/08/13/the-clean-architecture.html"),
 	}
 }
 
@@ -154,10 +361,12 @@ func CleanArchitecture(domainNamespace, applicationNamespace, infrastructureName
 func HexagonalArchitecture(domainNamespace, portsNamespace, adaptersNamespace string) *ArchitecturePattern {
 	return &ArchitecturePattern{
 		Name: "Hexagonal Architecture",
-		Rules: []Rule{
+		Rules: annotate([]Rule{
 			// Domain should not depend on ports or adapters
 			{
+				ID:          "hexagonal/domain-not-depend-on-ports",
 				Description: fmt.Sprintf("Domain layer (%s) should not depend on ports layer (%s)", domainNamespace, portsNamespace),
+				Rationale:   "The domain is the center of the hexagon and must not depend on the ports around it.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(domainNamespace).
@@ -167,7 +376,9 @@ func HexagonalArchitecture(domainNamespace, portsNamespace, adaptersNamespace st
 				},
 			},
 			{
+				ID:          "hexagonal/domain-not-depend-on-adapters",
 				Description: fmt.Sprintf("Domain layer (%s) should not depend on adapters layer (%s)", domainNamespace, adaptersNamespace),
+				Rationale:   "Adapters are replaceable technical details and must never leak into the domain.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(domainNamespace).
@@ -178,7 +389,9 @@ func HexagonalArchitecture(domainNamespace, portsNamespace, adaptersNamespace st
 			},
 			// Domain should be independent
 			{
+				ID:          "hexagonal/domain-exists",
 				Description: fmt.Sprintf("Domain layer (%s) should exist", domainNamespace),
+				Rationale:   "A hexagonal application is built around a domain; an empty domain usually means a misconfigured namespace.",
 				Validate: func(types *Types) *Result {
 					domainTypes := types.That().ResideInNamespace(domainNamespace).types
 					if len(domainTypes) == 0 {
@@ -200,7 +413,9 @@ func HexagonalArchitecture(domainNamespace, portsNamespace, adaptersNamespace st
 			},
 			// Adapters should not be used directly by domain
 			{
+				ID:          "hexagonal/adapters-implement-port",
 				Description: fmt.Sprintf("Adapters (%s) should implement a Port interface", adaptersNamespace),
+				Rationale:   "Adapters exist to connect the application to the outside world through ports.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(adaptersNamespace).
@@ -209,7 +424,7 @@ func HexagonalArchitecture(domainNamespace, portsNamespace, adaptersNamespace st
 						GetResult()
 				},
 			},
-		},
+		}, []string{"hexagonal", "ports-and-adapters"}, "https://alistair.cockburn.us/hexagonal-architecture/"),
 	}
 }
 
@@ -230,7 +445,9 @@ func LayeredArchitecture(layers ...string) *ArchitecturePattern {
 
 			// Create a rule with description and validation function
 			rule := Rule{
+				ID:          ruleID("layered", currentLayer, "not-depend-on", higherLayer),
 				Description: fmt.Sprintf("Layer %s should not depend on higher layer %s", currentLayer, higherLayer),
+				Rationale:   "Lower layers must not depend on the layers built on top of them.",
 				Validate: func(current, higher string) func(*Types) *Result {
 					return func(types *Types) *Result {
 						return types.That().
@@ -248,7 +465,7 @@ func LayeredArchitecture(layers ...string) *ArchitecturePattern {
 
 	return &ArchitecturePattern{
 		Name:  fmt.Sprintf("Layered Architecture (%s)", strings.Join(layers, " -> ")),
-		Rules: rules,
+		Rules: annotate(rules, []string{"layered"}),
 	}
 }
 
@@ -256,10 +473,12 @@ func LayeredArchitecture(layers ...string) *ArchitecturePattern {
 func MVCArchitecture(modelNamespace, viewNamespace, controllerNamespace string) *ArchitecturePattern {
 	return &ArchitecturePattern{
 		Name: "MVC Architecture",
-		Rules: []Rule{
+		Rules: annotate([]Rule{
 			// Models should not depend on views or controllers
 			{
+				ID:          "mvc/model-not-depend-on-view",
 				Description: fmt.Sprintf("Model layer (%s) should not depend on view layer (%s)", modelNamespace, viewNamespace),
+				Rationale:   "Models must be reusable by any view.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(modelNamespace).
@@ -269,7 +488,9 @@ func MVCArchitecture(modelNamespace, viewNamespace, controllerNamespace string)
 				},
 			},
 			{
+				ID:          "mvc/model-not-depend-on-controller",
 				Description: fmt.Sprintf("Model layer (%s) should not depend on controller layer (%s)", modelNamespace, controllerNamespace),
+				Rationale:   "Models must not know which controller manipulates them.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(modelNamespace).
@@ -280,7 +501,9 @@ func MVCArchitecture(modelNamespace, viewNamespace, controllerNamespace string)
 			},
 			// Views should not depend on controllers
 			{
+				ID:          "mvc/view-not-depend-on-controller",
 				Description: fmt.Sprintf("View layer (%s) should not depend on controller layer (%s)", viewNamespace, controllerNamespace),
+				Rationale:   "Views only render models; the controller drives the interaction.",
 				Validate: func(types *Types) *Result {
 					return types.That().
 						ResideInNamespace(viewNamespace).
@@ -289,99 +512,21 @@ func MVCArchitecture(modelNamespace, viewNamespace, controllerNamespace string)
 						GetResult()
 				},
 			},
-		},
+		}, []string{"mvc"}),
 	}
 }
 
-// DDDWithCleanArchitecture defines a Domain-Driven Design pattern with Clean Architecture within each bounded context
+// DDDWithCleanArchitecture defines a Domain-Driven Design pattern with Clean Architecture within each bounded context.
+// The bounded contexts live in internal/<domain>/{domain,application,infrastructure}.
 // This pattern enforces:
 // 1. Bounded contexts are isolated from each other (no cross-domain dependencies)
 // 2. Within each domain: Clean Architecture layers (domain -> application -> infrastructure)
-// 3. Shared kernel can be used by all domains
-// 4. pkg/ contains reusable utilities that can be used by any layer
+// 3. Shared kernel can be used by all domains, but does not depend on any of them
+// 4. pkg/ contains reusable utilities that can be used by any layer, and does not import internal packages
+//
+// Use DDDArchitecture to configure the root, the layers and extra layers.
 func DDDWithCleanArchitecture(domains []string, sharedNamespace, pkgNamespace string) *ArchitecturePattern {
-	var rules []Rule
-
-	// Rule 1: Domain layers should not depend on application or infrastructure within the same domain
-	for _, domain := range domains {
-		domainNS := fmt.Sprintf("internal/%s/domain", domain)
-		applicationNS := fmt.Sprintf("internal/%s/application", domain)
-		infrastructureNS := fmt.Sprintf("internal/%s/infrastructure", domain)
-
-		// Domain should not depend on application
-		rules = append(rules, Rule{
-			Description: fmt.Sprintf("Domain layer (%s) should not depend on application layer (%s)", domainNS, applicationNS),
-			Validate: func(domainNS, applicationNS string) func(*Types) *Result {
-				return func(types *Types) *Result {
-					return types.That().
-						ResideInNamespace(domainNS).
-						ShouldNot().
-						HaveDependencyOn(applicationNS).
-						GetResult()
-				}
-			}(domainNS, applicationNS),
-		})
-
-		// Domain should not depend on infrastructure
-		rules = append(rules, Rule{
-			Description: fmt.Sprintf("Domain layer (%s) should not depend on infrastructure layer (%s)", domainNS, infrastructureNS),
-			Validate: func(domainNS, infrastructureNS string) func(*Types) *Result {
-				return func(types *Types) *Result {
-					return types.That().
-						ResideInNamespace(domainNS).
-						ShouldNot().
-						HaveDependencyOn(infrastructureNS).
-						GetResult()
-				}
-			}(domainNS, infrastructureNS),
-		})
-
-		// Application should not depend on infrastructure
-		rules = append(rules, Rule{
-			Description: fmt.Sprintf("Application layer (%s) should not depend on infrastructure layer (%s)", applicationNS, infrastructureNS),
-			Validate: func(applicationNS, infrastructureNS string) func(*Types) *Result {
-				return func(types *Types) *Result {
-					return types.That().
-						ResideInNamespace(applicationNS).
-						ShouldNot().
-						HaveDependencyOn(infrastructureNS).
-						GetResult()
-				}
-			}(applicationNS, infrastructureNS),
-		})
-	}
-
-	// Rule 2: Cross-domain dependencies are not allowed (bounded context isolation)
-	for i, domain1 := range domains {
-		for j, domain2 := range domains {
-			if i != j {
-				domain1Prefix := fmt.Sprintf("internal/%s", domain1)
-				domain2Prefix := fmt.Sprintf("internal/%s", domain2)
-
-				rules = append(rules, Rule{
-					Description: fmt.Sprintf("Domain %s should not depend on domain %s (bounded context isolation)", domain1, domain2),
-					Validate: func(d1, d2 string) func(*Types) *Result {
-						return func(types *Types) *Result {
-							return types.That().
-								ResideInNamespace(d1).
-								ShouldNot().
-								HaveDependencyOn(d2).
-								GetResult()
-						}
-					}(domain1Prefix, domain2Prefix),
-				})
-			}
-		}
-	}
-
-	// Rule 3: Shared kernel can be used by any layer (this is the purpose of shared kernel in DDD)
-	// No restrictions - shared kernel is meant to be shared across bounded contexts and layers
-	// Note: We don't add restrictive rules here because shared kernel is designed to be widely accessible
-
-	return &ArchitecturePattern{
-		Name:  fmt.Sprintf("DDD with Clean Architecture (domains: %s)", strings.Join(domains, ", ")),
-		Rules: rules,
-	}
+	return DDDArchitecture(domains, WithDDDSharedKernel(sharedNamespace), WithDDDPkg(pkgNamespace))
 }
 
 // CQRSArchitecture defines the Command Query Responsibility Segregation pattern
@@ -397,7 +542,9 @@ func CQRSArchitecture(commandNamespace, queryNamespace, domainNamespace, writeMo
 
 	// Rule 1: Commands should not depend on queries (separation of concerns)
 	rules = append(rules, Rule{
+		ID:          "cqrs/commands-not-depend-on-queries",
 		Description: fmt.Sprintf("Commands (%s) should not depend on queries (%s) - separation of concerns", commandNamespace, queryNamespace),
+		Rationale:   "Commands and queries evolve independently and must stay segregated.",
 		Validate: func(types *Types) *Result {
 			return types.That().
 				ResideInNamespace(commandNamespace).
@@ -409,7 +556,9 @@ func CQRSArchitecture(commandNamespace, queryNamespace, domainNamespace, writeMo
 
 	// Rule 2: Queries should not depend on commands (separation of concerns)
 	rules = append(rules, Rule{
+		ID:          "cqrs/queries-not-depend-on-commands",
 		Description: fmt.Sprintf("Queries (%s) should not depend on commands (%s) - separation of concerns", queryNamespace, commandNamespace),
+		Rationale:   "Queries must not trigger state changes.",
 		Validate: func(types *Types) *Result {
 			return types.That().
 				ResideInNamespace(queryNamespace).
@@ -422,7 +571,9 @@ func CQRSArchitecture(commandNamespace, queryNamespace, domainNamespace, writeMo
 	// Rule 3: Write models should not depend on read models
 	if writeModelNamespace != "" && readModelNamespace != "" {
 		rules = append(rules, Rule{
+			ID:          "cqrs/write-models-not-depend-on-read-models",
 			Description: fmt.Sprintf("Write models (%s) should not depend on read models (%s)", writeModelNamespace, readModelNamespace),
+			Rationale:   "The write model must not be shaped by how data is read.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(writeModelNamespace).
@@ -434,7 +585,9 @@ func CQRSArchitecture(commandNamespace, queryNamespace, domainNamespace, writeMo
 
 		// Rule 4: Read models should not depend on write models
 		rules = append(rules, Rule{
+			ID:          "cqrs/read-models-not-depend-on-write-models",
 			Description: fmt.Sprintf("Read models (%s) should not depend on write models (%s)", readModelNamespace, writeModelNamespace),
+			Rationale:   "Read models are projections optimized for queries and must not couple to aggregates.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(readModelNamespace).
@@ -446,7 +599,9 @@ func CQRSArchitecture(commandNamespace, queryNamespace, domainNamespace, writeMo
 
 		// Rule 5: Commands should primarily use write models
 		rules = append(rules, Rule{
+			ID:          "cqrs/commands-not-depend-on-read-models",
 			Description: fmt.Sprintf("Commands (%s) should not depend on read models (%s)", commandNamespace, readModelNamespace),
+			Rationale:   "Commands must validate against the write model, which is the source of truth.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(commandNamespace).
@@ -458,7 +613,9 @@ func CQRSArchitecture(commandNamespace, queryNamespace, domainNamespace, writeMo
 
 		// Rule 6: Queries should primarily use read models
 		rules = append(rules, Rule{
+			ID:          "cqrs/queries-not-depend-on-write-models",
 			Description: fmt.Sprintf("Queries (%s) should not depend on write models (%s)", queryNamespace, writeModelNamespace),
+			Rationale:   "Queries must be served from read models.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(queryNamespace).
@@ -474,7 +631,7 @@ func CQRSArchitecture(commandNamespace, queryNamespace, domainNamespace, writeMo
 
 	return &ArchitecturePattern{
 		Name:  "CQRS Architecture",
-		Rules: rules,
+		Rules: annotate(rules, []string{"cqrs"}, "https://martinfowler.com/bliki/CQRS.html"),
 	}
 }
 
@@ -495,7 +652,9 @@ func EventSourcedCQRSArchitecture(commandNamespace, queryNamespace, eventNamespa
 	// Rule 1: Commands should have dependency on events namespace (to produce them)
 	if eventNamespace != "" {
 		rules = append(rules, Rule{
+			ID:          "event-sourced-cqrs/commands-depend-on-events",
 			Description: fmt.Sprintf("Commands (%s) should depend on events (%s) to produce them", commandNamespace, eventNamespace),
+			Rationale:   "Commands record state changes as events.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(commandNamespace).
@@ -509,7 +668,9 @@ func EventSourcedCQRSArchitecture(commandNamespace, queryNamespace, eventNamespa
 	// Rule 2: Commands should interact with event store
 	if eventStoreNamespace != "" {
 		rules = append(rules, Rule{
+			ID:          "event-sourced-cqrs/commands-depend-on-event-store",
 			Description: fmt.Sprintf("Commands (%s) should depend on event store (%s)", commandNamespace, eventStoreNamespace),
+			Rationale:   "The event store is the source of truth for the write side.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(commandNamespace).
@@ -523,7 +684,9 @@ func EventSourcedCQRSArchitecture(commandNamespace, queryNamespace, eventNamespa
 	// Rule 3: Queries should not depend on event store directly (use projections instead)
 	if eventStoreNamespace != "" {
 		rules = append(rules, Rule{
+			ID:          "event-sourced-cqrs/queries-not-depend-on-event-store",
 			Description: fmt.Sprintf("Queries (%s) should not depend on event store (%s) directly", queryNamespace, eventStoreNamespace),
+			Rationale:   "Queries must read from projections instead of replaying events.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(queryNamespace).
@@ -537,7 +700,9 @@ func EventSourcedCQRSArchitecture(commandNamespace, queryNamespace, eventNamespa
 	// Rule 4: Projections should depend on events (to build read models)
 	if projectionNamespace != "" && eventNamespace != "" {
 		rules = append(rules, Rule{
+			ID:          "event-sourced-cqrs/projections-depend-on-events",
 			Description: fmt.Sprintf("Projections (%s) should depend on events (%s) to build read models", projectionNamespace, eventNamespace),
+			Rationale:   "Projections build read models from events.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(projectionNamespace).
@@ -551,7 +716,9 @@ func EventSourcedCQRSArchitecture(commandNamespace, queryNamespace, eventNamespa
 	// Rule 5: Queries should depend on projections (not directly on events)
 	if projectionNamespace != "" {
 		rules = append(rules, Rule{
+			ID:          "event-sourced-cqrs/queries-depend-on-projections",
 			Description: fmt.Sprintf("Queries (%s) should depend on projections (%s) not directly on events", queryNamespace, projectionNamespace),
+			Rationale:   "Queries should be served from projections, not directly from events.",
 			Validate: func(types *Types) *Result {
 				return types.That().
 					ResideInNamespace(queryNamespace).
@@ -564,6 +731,6 @@ func EventSourcedCQRSArchitecture(commandNamespace, queryNamespace, eventNamespa
 
 	return &ArchitecturePattern{
 		Name:  "Event Sourced CQRS Architecture",
-		Rules: rules,
+		Rules: annotate(rules, []string{"cqrs", "event-sourcing"}, "https://martinfowler.com/eaaDev/EventSourcing.html"),
 	}
 }
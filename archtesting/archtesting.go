@@ -0,0 +1,170 @@
+// Package archtesting integrates goarchtest with the standard testing package.
+//
+// It replaces the loop every architecture test repeats: check IsSuccessful, report
+// the failure and log each failing type. All helpers call t.Helper(), so failures are
+// reported at the caller, and print the evidence of every violation.
+//
+// Example:
+//
+//	func TestArchitecture(t *testing.T) {
+//	    types := goarchtest.InPath("./")
+//
+//	    archtesting.AssertPattern(t, goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation"), types)
+//
+//	    archtesting.AssertRule(t, types.That().
+//	        ResideInNamespace("domain").
+//	        ShouldNot().
+//	        HaveDependencyOn("infrastructure").
+//	        GetResult())
+//	}
+package archtesting
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/solrac97gr/goarchtest"
+)
+
+// AssertRule reports a failed rule as a test error, with one line per violation.
+// Warnings and exemptions of the result are logged.
+//
+// Parameters:
+//   - t: The test to report to
+//   - result: The result of the rule
+//
+// Returns:
+//   - bool: Whether the rule passed
+func AssertRule(t testing.TB, result *goarchtest.Result) bool {
+	t.Helper()
+
+	logNotes(t, result.Warnings, result.Exemptions, 0)
+	if result.IsSuccessful {
+		return true
+	}
+
+	t.Error(formatFailure(result.Description, result.Err, result.Violations, result.FailingTypes))
+	return false
+}
+
+// RequireNoViolations is like AssertRule, but stops the test when the rule failed
+//
+// Parameters:
+//   - t: The test to report to
+//   - result: The result of the rule
+func RequireNoViolations(t testing.TB, result *goarchtest.Result) {
+	t.Helper()
+
+	if !AssertRule(t, result) {
+		t.FailNow()
+	}
+}
+
+// AssertPattern validates a pattern and runs one subtest per rule, named from
+// the rule's description. See AssertResults for how each rule is reported.
+//
+// Parameters:
+//   - t: The test to report to
+//   - pattern: The architecture pattern to validate
+//   - types: The types to validate the pattern against
+//
+// Returns:
+//   - bool: Whether every rule with error severity passed
+func AssertPattern(t *testing.T, pattern *goarchtest.ArchitecturePattern, types *goarchtest.Types) bool {
+	t.Helper()
+
+	return AssertResults(t, pattern.Validate(types))
+}
+
+// AssertResults runs one subtest per validation result, named from the rule's description.
+// Failed rules with error severity fail their subtest; failed rules with warning or
+// info severity are only logged. Use it instead of AssertPattern to report results
+// that were post-processed, e.g. by goarchtest.FreezeViolations.
+//
+// Parameters:
+//   - t: The test to report to
+//   - results: The validation results of a pattern
+//
+// Returns:
+//   - bool: Whether every rule with error severity passed
+func AssertResults(t *testing.T, results []*goarchtest.ValidationResult) bool {
+	t.Helper()
+
+	passed := true
+	for _, result := range results {
+		name := result.RuleDescription
+		if name == "" {
+			name = result.RuleID
+		}
+
+		passed = t.Run(name, func(t *testing.T) {
+			t.Helper()
+			assertValidationResult(t, result)
+		}) && passed
+	}
+	return passed
+}
+
+// assertValidationResult reports a single validation result, honoring its severity
+func assertValidationResult(t testing.TB, result *goarchtest.ValidationResult) {
+	t.Helper()
+
+	logNotes(t, result.Warnings, result.Exemptions, result.FrozenCount)
+	if result.IsSuccessful {
+		return
+	}
+
+	failure := formatFailure(result.RuleDescription, result.Err, result.Violations, result.FailingTypes)
+	if result.RuleID != "" {
+		failure = fmt.Sprintf("[%s] %s", result.RuleID, failure)
+	}
+
+	if result.IsBlocking() {
+		t.Error(failure)
+		return
+	}
+	t.Logf("%s: %s", result.Severity, failure)
+}
+
+// formatFailure describes a failed rule and lists its violations
+func formatFailure(description string, err error, violations []goarchtest.Violation, failingTypes []*goarchtest.TypeInfo) string {
+	if err != nil {
+		return fmt.Sprintf("invalid rule %q: %v", description, err)
+	}
+
+	var failure strings.Builder
+	failure.WriteString(fmt.Sprintf("architecture rule failed: %s", description))
+
+	if len(violations) == 0 {
+		for _, failingType := range failingTypes {
+			failure.WriteString(fmt.Sprintf("\n  - %s in package %s", failingType.Name, failingType.FullPath))
+		}
+		return failure.String()
+	}
+
+	for _, v := range violations {
+		failure.WriteString(fmt.Sprintf("\n  - %s in package %s", v.Type.Name, v.Type.FullPath))
+		if v.Reason != "" {
+			failure.WriteString(": " + v.Reason)
+		} else if v.Evidence != "" {
+			failure.WriteString(": " + v.Evidence)
+		}
+	}
+	return failure.String()
+}
+
+// logNotes logs the warnings, exemptions and frozen violations of a result
+func logNotes(t testing.TB, warnings []string, exemptions []goarchtest.Exemption, frozenCount int) {
+	t.Helper()
+
+	for _, warning := range warnings {
+		t.Logf("warning: %s", warning)
+	}
+	for _, exemption := range exemptions {
+		t.Logf("exempted: %s in package %s (%s)", exemption.Type.Name, exemption.Type.Package, exemption.Reason)
+	}
+	if frozenCount > 0 {
+		t.Logf("frozen: %d violation(s) in baseline", frozenCount)
+	}
+}
@@ -0,0 +1,184 @@
+package goarchtest
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// baselineVersion is the version of the baseline file format
+const baselineVersion = 1
+
+// Baseline is the content of a baseline (freeze) file: the violations that
+// existed when a rule was adopted and are tolerated until they are fixed
+type Baseline struct {
+	Version    int             `json:"version"`
+	Violations []BaselineEntry `json:"violations"`
+}
+
+// BaselineEntry identifies one frozen violation
+type BaselineEntry struct {
+	RuleID   string `json:"ruleId"`
+	Type     string `json:"type"`
+	Evidence string `json:"evidence,omitempty"`
+}
+
+// FreezeViolations applies the baseline file at path to the validation results,
+// so rules can be adopted on a codebase that already violates them.
+//
+// On the first run, when the file does not exist, every current violation is
+// recorded into it. On later runs violations found in the baseline are frozen:
+// they are removed from the results and counted in FrozenCount, and a rule only
+// fails on new violations. Baseline entries whose violation was fixed are dropped
+// and the file is rewritten, so the baseline shrinks as the code improves. Entries
+// of rules that are not part of the results are kept untouched.
+//
+// Violations are identified by rule ID, the full path of the type and the evidence.
+// Failing types without a recorded violation, as in results built by hand, are
+// frozen as violations without evidence. Results of invalid rules (with Err set)
+// are never frozen.
+//
+// Parameters:
+//   - path: The baseline file, usually committed to the repository
+//   - results: The validation results to apply the baseline to; they are updated in place
+//
+// Returns:
+//   - error: An error if the baseline file cannot be read or written
+//
+// Example:
+//
+//	results := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation").Validate(types)
+//	if err := goarchtest.FreezeViolations("archtest-baseline.json", results); err != nil {
+//	    t.Fatal(err)
+//	}
+func FreezeViolations(path string, results []*ValidationResult) error {
+	baseline, err := LoadBaseline(path)
+	recording := errors.Is(err, fs.ErrNotExist)
+	if err != nil && !recording {
+		return err
+	}
+
+	evaluated := make(map[string]bool)
+	var current []BaselineEntry
+	for _, result := range results {
+		if result.Err != nil {
+			continue
+		}
+		evaluated[result.RuleID] = true
+		for _, v := range withFailingTypes(result.FailingTypes, result.Violations) {
+			current = append(current, baselineEntry(result.RuleID, v))
+		}
+	}
+
+	if recording {
+		baseline = &Baseline{Violations: current}
+	}
+
+	frozen := make(map[BaselineEntry]bool, len(baseline.Violations))
+	for _, entry := range baseline.Violations {
+		frozen[entry] = true
+	}
+
+	for _, result := range results {
+		if result.Err == nil {
+			freeze(result, frozen)
+		}
+	}
+
+	// Keep the entries of rules that were not evaluated, and those still violated
+	present := make(map[BaselineEntry]bool, len(current))
+	for _, entry := range current {
+		present[entry] = true
+	}
+	var remaining []BaselineEntry
+	for _, entry := range baseline.Violations {
+		if !evaluated[entry.RuleID] || present[entry] {
+			remaining = append(remaining, entry)
+		}
+	}
+
+	if !recording && len(remaining) == len(baseline.Violations) {
+		return nil
+	}
+	return (&Baseline{Violations: remaining}).Save(path)
+}
+
+// freeze removes the frozen violations from a result, failing it only for new ones
+func freeze(result *ValidationResult, frozen map[BaselineEntry]bool) {
+	current := withFailingTypes(result.FailingTypes, result.Violations)
+	if len(current) == 0 {
+		return
+	}
+
+	var violations []Violation
+	var failingTypes []*TypeInfo
+	for _, v := range current {
+		if frozen[baselineEntry(result.RuleID, v)] {
+			result.FrozenCount++
+			continue
+		}
+		violations = append(violations, v)
+		if v.Type != nil && !slices.Contains(failingTypes, v.Type) {
+			failingTypes = append(failingTypes, v.Type)
+		}
+	}
+
+	result.Violations = violations
+	result.FailingTypes = failingTypes
+	result.IsSuccessful = len(violations) == 0
+}
+
+// baselineEntry identifies a violation of a rule
+func baselineEntry(ruleID string, v Violation) BaselineEntry {
+	return BaselineEntry{RuleID: ruleID, Type: typeKey(v.Type), Evidence: v.Evidence}
+}
+
+// LoadBaseline reads a baseline file.
+// The returned error wraps fs.ErrNotExist when the file does not exist.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("invalid baseline file %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline file version %d in %s", baseline.Version, path)
+	}
+
+	return &baseline, nil
+}
+
+// Save writes the baseline to a file, with entries sorted so that the file
+// produces stable diffs when committed
+func (b *Baseline) Save(path string) error {
+	entries := slices.Clone(b.Violations)
+	slices.SortFunc(entries, compareBaselineEntries)
+	entries = slices.Compact(entries)
+	if entries == nil {
+		entries = []BaselineEntry{}
+	}
+
+	data, err := json.MarshalIndent(Baseline{Version: baselineVersion, Violations: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// compareBaselineEntries orders entries by rule ID, type and evidence
+func compareBaselineEntries(a, b BaselineEntry) int {
+	return cmp.Or(
+		cmp.Compare(a.RuleID, b.RuleID),
+		cmp.Compare(a.Type, b.Type),
+		cmp.Compare(a.Evidence, b.Evidence),
+	)
+}
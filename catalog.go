@@ -0,0 +1,113 @@
+package goarchtest
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// builtinRegistry creates the global registry with the catalog of built-in conventions.
+//
+// Predicates:
+//   - "repository": types named *Repository or *Repo
+//   - "handler": types named *Handler or *Controller
+//   - "service": types named *Service
+//   - "useCase": types named *UseCase or *Interactor
+//   - "dto": types named *DTO, *Request or *Response
+//   - "entity": structs with an identity field (ID or Id)
+//   - "valueObject": structs without an identity field whose methods all have value receivers
+//   - "immutable": structs without pointer-receiver methods
+//
+// Conditions:
+//   - "exported": the type is exported
+func builtinRegistry() *Registry {
+	r := newRegistry(nil)
+
+	r.RegisterPredicate("repository", nameEndingWithAny("Repository", "Repo"))
+	r.RegisterPredicate("handler", nameEndingWithAny("Handler", "Controller"))
+	r.RegisterPredicate("service", nameEndingWithAny("Service"))
+	r.RegisterPredicate("useCase", nameEndingWithAny("UseCase", "Interactor"))
+	r.RegisterPredicate("dto", nameEndingWithAny("DTO", "Dto", "Request", "Response"))
+
+	r.RegisterContextPredicate("entity", func(ctx *PredicateContext, t *TypeInfo) bool {
+		s := structOf(ctx.Object())
+		return s != nil && hasIdentityField(s)
+	})
+	r.RegisterContextPredicate("valueObject", func(ctx *PredicateContext, t *TypeInfo) bool {
+		obj := ctx.Object()
+		s := structOf(obj)
+		return s != nil && s.NumFields() > 0 && !hasIdentityField(s) && len(pointerMethods(obj)) == 0
+	})
+	r.RegisterContextPredicate("immutable", func(ctx *PredicateContext, t *TypeInfo) bool {
+		obj := ctx.Object()
+		if structOf(obj) == nil {
+			return false
+		}
+		if methods := pointerMethods(obj); len(methods) > 0 {
+			ctx.Reason("%s has pointer-receiver methods: %s", t.Name, strings.Join(methods, ", "))
+			return false
+		}
+		return true
+	})
+
+	r.RegisterCondition("exported", func(t *TypeInfo) (bool, string) {
+		if token.IsExported(t.Name) {
+			return true, t.Name + " is exported"
+		}
+		return false, t.Name + " is not exported"
+	})
+
+	return r
+}
+
+// nameEndingWithAny returns a predicate matching type names with any of the suffixes
+func nameEndingWithAny(suffixes ...string) CustomPredicate {
+	return func(t *TypeInfo) bool {
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(t.Name, suffix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// structOf returns the struct underlying a type name, or nil
+func structOf(obj types.Object) *types.Struct {
+	if _, ok := obj.(*types.TypeName); !ok {
+		return nil
+	}
+	s, _ := obj.Type().Underlying().(*types.Struct)
+	return s
+}
+
+// hasIdentityField reports whether a struct has an ID or Id field
+func hasIdentityField(s *types.Struct) bool {
+	for i := 0; i < s.NumFields(); i++ {
+		if name := s.Field(i).Name(); name == "ID" || name == "Id" {
+			return true
+		}
+	}
+	return false
+}
+
+// pointerMethods returns the names of the methods declared with a pointer receiver
+func pointerMethods(obj types.Object) []string {
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	var methods []string
+	for i := 0; i < named.NumMethods(); i++ {
+		method := named.Method(i)
+		signature, ok := method.Type().(*types.Signature)
+		if !ok || signature.Recv() == nil {
+			continue
+		}
+		if _, isPointer := signature.Recv().Type().(*types.Pointer); isPointer {
+			methods = append(methods, method.Name())
+		}
+	}
+	return methods
+}
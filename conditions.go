@@ -0,0 +1,121 @@
+package goarchtest
+
+import "fmt"
+
+// Condition represents a custom condition: it reports whether a type satisfies
+// it and, when it does not, why
+type Condition func(*TypeInfo) (ok bool, reason string)
+
+// MultiCondition represents a custom condition that can find several problems
+// with one type. A type satisfies it when no reasons are returned.
+type MultiCondition func(*TypeInfo) (reasons []string)
+
+// Satisfy checks a custom condition. Unlike WithCustomPredicate, which filters,
+// it is a condition: every selected type must satisfy it, and the reason returned
+// for each type that does not is reported as its Violation.
+//
+// After ShouldNot, the types satisfying the condition fail; the reason returned
+// for them is reported instead.
+//
+// Parameters:
+//   - name: A string identifier for the condition, used in the rule's description
+//   - condition: A Condition function evaluated for each selected type
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet containing only types that satisfy the condition,
+//     allowing for method chaining
+//
+// Example:
+//
+//	result := types.That().
+//	    ResideInNamespace("domain").
+//	    Should().
+//	    Satisfy("have an ID field", func(t *goarchtest.TypeInfo) (bool, string) {
+//	        if hasIDField(t) {
+//	            return true, ""
+//	        }
+//	        return false, fmt.Sprintf("entity %s has no ID field", t.Name)
+//	    }).
+//	    GetResult()
+func (ts *TypeSet) Satisfy(name string, condition Condition) *TypeSet {
+	ts.currentPredicate = name
+	return ts.satisfied(fmt.Sprintf("satisfy '%s'", name), ts.filterWithCondition(condition))
+}
+
+// filterWithCondition returns the types satisfying the condition,
+// recording the reasons the condition gave as evidence
+func (ts *TypeSet) filterWithCondition(condition Condition) []*TypeInfo {
+	var filteredTypes []*TypeInfo
+	for _, t := range ts.types {
+		ok, reason := condition(t)
+		if ok {
+			filteredTypes = append(filteredTypes, t)
+		}
+
+		if reason == "" {
+			continue
+		}
+		if ok {
+			ts.recordMatch(Violation{Type: t, Reason: reason})
+		} else {
+			ts.recordMiss(Violation{Type: t, Reason: reason})
+		}
+	}
+	return filteredTypes
+}
+
+// SatisfyEach checks a custom condition that can report several violations per type.
+// Every selected type must satisfy it, and each returned reason is reported as a
+// separate Violation. See Satisfy for how conditions differ from filters.
+//
+// Parameters:
+//   - name: A string identifier for the condition, used in the rule's description
+//   - condition: A MultiCondition function evaluated for each selected type
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet containing only types that satisfy the condition,
+//     allowing for method chaining
+//
+// Example:
+//
+//	result := types.That().
+//	    HaveNameEndingWith("Handler").
+//	    Should().
+//	    SatisfyEach("follow handler conventions", func(t *goarchtest.TypeInfo) []string {
+//	        var reasons []string
+//	        if !t.IsStruct {
+//	            reasons = append(reasons, t.Name+" is not a struct")
+//	        }
+//	        if t.Package != "handlers" {
+//	            reasons = append(reasons, t.Name+" is not in the handlers package")
+//	        }
+//	        return reasons
+//	    }).
+//	    GetResult()
+func (ts *TypeSet) SatisfyEach(name string, condition MultiCondition) *TypeSet {
+	ts.currentPredicate = name
+
+	var filteredTypes []*TypeInfo
+	for _, t := range ts.types {
+		reasons := condition(t)
+		if len(reasons) == 0 {
+			filteredTypes = append(filteredTypes, t)
+			continue
+		}
+
+		for _, reason := range reasons {
+			ts.recordMiss(Violation{Type: t, Reason: reason})
+		}
+	}
+
+	return ts.satisfied(fmt.Sprintf("satisfy '%s'", name), filteredTypes)
+}
+
+// satisfied completes a custom condition, keeping the types that satisfy it
+func (ts *TypeSet) satisfied(phrase string, filteredTypes []*TypeInfo) *TypeSet {
+	ts.types = filteredTypes
+	ts.strict = true
+	ts.describe(phrase)
+	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
+	return ts
+}
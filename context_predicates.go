@@ -0,0 +1,167 @@
+package goarchtest
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ContextPredicate represents a custom predicate with access to the whole model.
+// Unlike CustomPredicate, it can look beyond a single type: at every loaded type,
+// at the reverse-dependency index and at the go/packages and go/types information.
+type ContextPredicate func(ctx *PredicateContext, t *TypeInfo) bool
+
+// PredicateContext gives a ContextPredicate access to the analyzed model.
+// A new context is passed for every evaluated type.
+type PredicateContext struct {
+	model  *Types
+	set    *TypeSet
+	typ    *TypeInfo
+	reason string
+}
+
+// Types returns every type of the analyzed model, not only the types of the chain
+func (ctx *PredicateContext) Types() []*TypeInfo {
+	if ctx.model == nil {
+		return nil
+	}
+	return ctx.model.That().GetAllTypes()
+}
+
+// TypesIn returns the types of the package with the given import path
+func (ctx *PredicateContext) TypesIn(pkgPath string) []*TypeInfo {
+	if ctx.model == nil || ctx.model.index == nil {
+		return nil
+	}
+	return slices.Clone(ctx.model.index.typesByPackage[pkgPath])
+}
+
+// ImportersOf returns the import paths of the loaded packages that import the
+// package with the given import path. See Types.ImportersOf.
+func (ctx *PredicateContext) ImportersOf(pkgPath string) []string {
+	if ctx.model == nil {
+		return nil
+	}
+	return ctx.model.ImportersOf(pkgPath)
+}
+
+// Package returns the loaded package of the evaluated type
+func (ctx *PredicateContext) Package() *packages.Package {
+	return ctx.PackageOf(ctx.typ)
+}
+
+// PackageOf returns the loaded package of any type of the model,
+// or nil if the package was not loaded.
+// In models loaded without type information it returns nil, and the rule
+// fails with ErrTypeInfoUnavailable.
+func (ctx *PredicateContext) PackageOf(t *TypeInfo) *packages.Package {
+	if ctx.model == nil || t == nil {
+		return nil
+	}
+	if !ctx.model.typeInfo {
+		if ctx.set != nil {
+			ctx.set.requireTypeInfo()
+		}
+		return nil
+	}
+	return ctx.model.packages[t.FullPath]
+}
+
+// Object returns the go/types object of the evaluated type
+func (ctx *PredicateContext) Object() types.Object {
+	return ctx.ObjectOf(ctx.typ)
+}
+
+// ObjectOf returns the go/types object of any type of the model,
+// or nil if the package has no type information.
+// Like PackageOf, it makes the rule fail in models loaded without type information.
+//
+// Example:
+//
+//	// Is the interface implemented by any struct of the model?
+//	isImplemented := func(ctx *goarchtest.PredicateContext, t *goarchtest.TypeInfo) bool {
+//	    iface, ok := ctx.Object().Type().Underlying().(*types.Interface)
+//	    if !ok {
+//	        return false
+//	    }
+//	    for _, other := range ctx.Types() {
+//	        if obj := ctx.ObjectOf(other); obj != nil && other.IsStruct &&
+//	            types.Implements(types.NewPointer(obj.Type()), iface) {
+//	            return true
+//	        }
+//	    }
+//	    ctx.Reason("%s has no implementation", t.Name)
+//	    return false
+//	}
+func (ctx *PredicateContext) ObjectOf(t *TypeInfo) types.Object {
+	pkg := ctx.PackageOf(t)
+	if pkg == nil || pkg.Types == nil {
+		return nil
+	}
+	return pkg.Types.Scope().Lookup(t.Name)
+}
+
+// Reason explains the outcome of the predicate for the evaluated type.
+// The reason is reported as the Violation of the type: for ShouldNot chains
+// when the predicate returned true, for Should chains when it returned false.
+func (ctx *PredicateContext) Reason(format string, args ...any) {
+	ctx.reason = fmt.Sprintf(format, args...)
+}
+
+// WithContextPredicate applies a model-aware custom predicate to filter the TypeSet.
+// Only types for which the predicate returns true are kept in the filtered set.
+//
+// Parameters:
+//   - name: A string identifier for the predicate being applied
+//   - predicate: A ContextPredicate function evaluated for each type in the set
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet itself to allow for method chaining
+//
+// Example:
+//
+//	// Packages nobody imports are dead code
+//	result := types.That().
+//	    ResideInNamespace("internal").
+//	    ShouldNot().
+//	    WithContextPredicate("isUnused", func(ctx *goarchtest.PredicateContext, t *goarchtest.TypeInfo) bool {
+//	        if len(ctx.ImportersOf(t.FullPath)) > 0 {
+//	            return false
+//	        }
+//	        ctx.Reason("package %s is not imported by any package", t.FullPath)
+//	        return true
+//	    }).
+//	    GetResult()
+func (ts *TypeSet) WithContextPredicate(name string, predicate ContextPredicate) *TypeSet {
+	ts.currentPredicate = name
+	ts.types = ts.filterWithContext(predicate)
+	ts.describe(fmt.Sprintf("satisfy '%s'", name))
+	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
+	return ts
+}
+
+// filterWithContext returns the types for which the predicate returns true,
+// recording the reasons the predicate gave as evidence
+func (ts *TypeSet) filterWithContext(predicate ContextPredicate) []*TypeInfo {
+	var filteredTypes []*TypeInfo
+	for _, t := range ts.types {
+		ctx := &PredicateContext{model: ts.model, set: ts, typ: t}
+		matched := predicate(ctx, t)
+
+		if matched {
+			filteredTypes = append(filteredTypes, t)
+		}
+
+		if ctx.reason == "" {
+			continue
+		}
+		if matched {
+			ts.recordMatch(Violation{Type: t, Reason: ctx.reason})
+		} else {
+			ts.recordMiss(Violation{Type: t, Reason: ctx.reason})
+		}
+	}
+	return filteredTypes
+}
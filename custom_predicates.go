@@ -1,5 +1,7 @@
 package goarchtest
 
+import "fmt"
+
 // CustomPredicate represents a custom predicate function
 type CustomPredicate func(*TypeInfo) bool
 
@@ -32,6 +34,7 @@ func (ts *TypeSet) WithCustomPredicate(name string, predicate CustomPredicate) *
 	}
 
 	ts.types = filteredTypes
+	ts.describe(fmt.Sprintf("satisfy '%s'", name))
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
@@ -0,0 +1,212 @@
+package goarchtest
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DDDOption configures the pattern created by DDDArchitecture
+type DDDOption func(*dddConfig)
+
+// dddConfig holds the settings applied through DDDOption values
+type dddConfig struct {
+	root         string
+	layers       []string
+	sharedKernel string
+	pkg          string
+}
+
+// WithDDDRoot sets the directory containing the bounded contexts. Default: "internal".
+// An empty root means the bounded contexts are top-level directories.
+func WithDDDRoot(root string) DDDOption {
+	return func(c *dddConfig) {
+		c.root = root
+	}
+}
+
+// WithDDDLayers sets the layers of each bounded context, ordered from the innermost
+// to the outermost. Every layer must not depend on the layers after it.
+// Default: "domain", "application", "infrastructure".
+//
+// Example:
+//
+//	goarchtest.DDDArchitecture(domains,
+//	    goarchtest.WithDDDLayers("domain", "ports", "application", "infrastructure", "presentation"),
+//	)
+func WithDDDLayers(layers ...string) DDDOption {
+	return func(c *dddConfig) {
+		c.layers = layers
+	}
+}
+
+// WithDDDSharedKernel sets the namespace of the shared kernel, which must not depend
+// on any bounded context. Default: "shared". An empty namespace disables the rules.
+func WithDDDSharedKernel(namespace string) DDDOption {
+	return func(c *dddConfig) {
+		c.sharedKernel = namespace
+	}
+}
+
+// WithDDDPkg sets the namespace of the reusable public packages, which must not
+// import internal packages. Default: "pkg". Packages of that name below an
+// internal package or the root, such as "internal/user/pkg", are not public
+// and are left out. An empty namespace disables the rule.
+func WithDDDPkg(namespace string) DDDOption {
+	return func(c *dddConfig) {
+		c.pkg = namespace
+	}
+}
+
+// DDDArchitecture defines a Domain-Driven Design pattern with Clean Architecture
+// layers within each bounded context.
+//
+// This pattern enforces:
+//  1. Within each bounded context, every layer does not depend on the layers outside it
+//     (by default domain -> application -> infrastructure)
+//  2. Bounded contexts are isolated from each other (no cross-context dependencies)
+//  3. The shared kernel, usable by every bounded context, does not depend on any of them
+//  4. The public packages in pkg/ do not import internal packages
+//
+// Parameters:
+//   - domains: The names of the bounded contexts, e.g. "user", "order"
+//   - opts: Options for the root, the layers, the shared kernel and pkg namespaces
+//
+// Returns:
+//   - *ArchitecturePattern: A pattern that validates the constraints above
+//
+// Example:
+//
+//	pattern := goarchtest.DDDArchitecture([]string{"user", "order"},
+//	    goarchtest.WithDDDRoot("contexts"),
+//	    goarchtest.WithDDDLayers("domain", "application", "infrastructure", "presentation"),
+//	)
+//	results := pattern.Validate(types)
+func DDDArchitecture(domains []string, opts ...DDDOption) *ArchitecturePattern {
+	cfg := &dddConfig{
+		root:         "internal",
+		layers:       []string{"domain", "application", "infrastructure"},
+		sharedKernel: "shared",
+		pkg:          "pkg",
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var rules []Rule
+
+	// Rule 1: Within each bounded context, layers depend only inward
+	for _, domain := range domains {
+		for i, inner := range cfg.layers {
+			for _, outer := range cfg.layers[i+1:] {
+				innerNS := path.Join(cfg.root, domain, inner)
+				outerNS := path.Join(cfg.root, domain, outer)
+
+				rules = append(rules, Rule{
+					ID:          ruleID("ddd", domain, inner, "not-depend-on", outer),
+					Description: fmt.Sprintf("%s layer (%s) should not depend on %s layer (%s)", layerTitle(inner), innerNS, outer, outerNS),
+					Rationale:   dddLayerRationale(inner, outer),
+					Validate:    shouldNotDependOn(innerNS, outerNS),
+				})
+			}
+		}
+	}
+
+	// Rule 2: Cross-domain dependencies are not allowed (bounded context isolation)
+	for i, domain1 := range domains {
+		for j, domain2 := range domains {
+			if i != j {
+				rules = append(rules, Rule{
+					ID:          ruleID("ddd", domain1, "not-depend-on", domain2),
+					Description: fmt.Sprintf("Domain %s should not depend on domain %s (bounded context isolation)", domain1, domain2),
+					Rationale:   "Bounded contexts must be isolated and communicate only through the shared kernel or integration events.",
+					Validate:    shouldNotDependOn(path.Join(cfg.root, domain1), path.Join(cfg.root, domain2)),
+				})
+			}
+		}
+	}
+
+	// Rule 3: The shared kernel can be used by every bounded context, so it must not depend on any of them
+	if cfg.sharedKernel != "" {
+		for _, domain := range domains {
+			rules = append(rules, Rule{
+				ID:          ruleID("ddd", "shared-kernel", "not-depend-on", domain),
+				Description: fmt.Sprintf("Shared kernel (%s) should not depend on domain %s", cfg.sharedKernel, domain),
+				Rationale:   "The shared kernel is used by every bounded context; depending on one of them couples them all.",
+				Validate:    shouldNotDependOn(cfg.sharedKernel, path.Join(cfg.root, domain)),
+			})
+		}
+	}
+
+	// Rule 4: pkg/ contains reusable packages, which must not import internal ones
+	if cfg.pkg != "" {
+		internal := []string{"internal"}
+		if cfg.root != "" && cfg.root != "internal" {
+			internal = append(internal, cfg.root)
+		}
+
+		for _, namespace := range internal {
+			rules = append(rules, Rule{
+				ID:          ruleID("ddd", "pkg", "not-depend-on", namespace),
+				Description: fmt.Sprintf("Public packages (%s) should not depend on %s packages", cfg.pkg, namespace),
+				Rationale:   "Packages in pkg/ are meant to be reused outside the module, where internal packages cannot be imported.",
+				Validate:    publicShouldNotDependOn(cfg.pkg, internal, namespace),
+			})
+		}
+	}
+
+	return &ArchitecturePattern{
+		Name:  fmt.Sprintf("DDD with Clean Architecture (domains: %s)", strings.Join(domains, ", ")),
+		Rules: annotate(rules, []string{"ddd"}, "https://martinfowler.com/bliki/BoundedContext.html"),
+	}
+}
+
+// shouldNotDependOn returns a rule validation checking that the types residing
+// in a namespace do not depend on another namespace
+func shouldNotDependOn(namespace, dependency string) func(*Types) *Result {
+	return func(types *Types) *Result {
+		return types.That().
+			ResideInNamespace(namespace).
+			ShouldNot().
+			HaveDependencyOn(dependency).
+			GetResult()
+	}
+}
+
+// publicShouldNotDependOn returns a rule validation checking that the types
+// residing in a public namespace do not depend on another namespace. Packages
+// of the public namespace nested in one of the internal namespaces are not public.
+func publicShouldNotDependOn(public string, internal []string, dependency string) func(*Types) *Result {
+	return func(types *Types) *Result {
+		selection := types.That().ResideInNamespace(public)
+		for _, namespace := range internal {
+			selection = selection.And().DoNotResideInNamespace(namespace + ".." + public + "..")
+		}
+		return selection.
+			ShouldNot().
+			HaveDependencyOn(dependency).
+			GetResult()
+	}
+}
+
+// layerTitle capitalizes a layer name for rule descriptions
+func layerTitle(layer string) string {
+	if layer == "" {
+		return layer
+	}
+	return strings.ToUpper(layer[:1]) + layer[1:]
+}
+
+// dddLayerRationale explains why an inner layer must not depend on an outer one
+func dddLayerRationale(inner, outer string) string {
+	switch {
+	case inner == "domain" && outer == "application":
+		return "The domain model of a bounded context must not depend on its use cases."
+	case inner == "domain" && outer == "infrastructure":
+		return "The domain model of a bounded context must stay free of technical details."
+	case inner == "application" && outer == "infrastructure":
+		return "Use cases should reach infrastructure through ports owned by the bounded context."
+	default:
+		return fmt.Sprintf("Dependencies within a bounded context point inward, so the %s layer must not know the %s layer.", inner, outer)
+	}
+}
@@ -69,6 +69,8 @@ The predicate system allows flexible filtering and testing of types:
   - HaveDependencyOn(dependency) - Filter types with specific dependencies
   - DoNotHaveDependencyOn(dependency) - Filter types without dependencies
   - ImplementInterface(interfaceName) - Filter types implementing interfaces
+  - BeAccessedBy(namespaces...) - Filter types whose package is imported from the namespaces
+  - OnlyBeAccessedBy(namespaces...) - Require that only the namespaces import the package
 
 ## Logical Operators
 
@@ -77,6 +79,16 @@ The predicate system allows flexible filtering and testing of types:
   - Should() - Specify positive conditions
   - ShouldNot() - Specify negative conditions (negation)
 
+## Exclusions
+
+  - Except(reason, other) - Exclude the types of another TypeSet
+  - ExceptTypes(reason, names...) - Exclude types by "package.Type" name
+  - ExceptNamespaces(reason, namespaces...) - Exclude types residing in the namespaces
+  - ExceptWhere(reason, predicate) - Exclude types matching a custom predicate
+
+Exclusions apply to the selection before Should/ShouldNot and to the condition after
+them. The reason is required and is echoed in Result.Exemptions.
+
 # Custom Predicates
 
 Create custom rules for specific architectural constraints:
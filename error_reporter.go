@@ -7,9 +7,13 @@ import (
 	"strings"
 )
 
-// ErrorReporter handles reporting of architecture test errors
+// ErrorReporter handles reporting of architecture test errors.
+// It writes a console summary with the formatter registered as "console",
+// ConsoleFormatter unless replaced with RegisterFormatter; SetFormatter makes
+// it write reports with any Formatter instead.
 type ErrorReporter struct {
-	writer io.Writer
+	writer    io.Writer
+	formatter Formatter
 }
 
 // NewErrorReporter creates a new ErrorReporter with the specified writer
@@ -22,67 +26,186 @@ func NewErrorReporter(writer io.Writer) *ErrorReporter {
 	}
 }
 
-// ReportError reports an error from an architecture test
+// SetFormatter makes the ErrorReporter write the results it is given as a
+// Report in the format of formatter, rather than its console summary.
+// A nil formatter restores the console summary.
+//
+// Example:
+//
+//	reporter := goarchtest.NewErrorReporter(os.Stdout)
+//	reporter.SetFormatter(goarchtest.MarkdownFormatter{})
+//	reporter.ReportPatternValidation(results)
+func (er *ErrorReporter) SetFormatter(formatter Formatter) {
+	er.formatter = formatter
+}
+
+// ReportError reports an error from an architecture test.
+// When description is empty, the description generated from the rule's chain is used.
 func (er *ErrorReporter) ReportError(result *Result, description string) {
-	if result.IsSuccessful {
-		return
+	described := *result
+	if description != "" {
+		described.Description = description
 	}
+	er.format(&Reporter{Results: []*Result{&described}})
+}
 
-	fmt.Fprintf(er.writer, "Architecture Test Failed: %s\n", description)
+// ReportPatternValidation reports the results of validating an architectural pattern
+func (er *ErrorReporter) ReportPatternValidation(results []*ValidationResult) {
+	if len(results) == 0 {
+		return
+	}
+	er.format(&Reporter{ValidationResults: results})
+}
 
-	if len(result.FailingTypes) > 0 {
-		fmt.Fprintln(er.writer, "Failing Types:")
+// format writes the report of the results with the formatter of the
+// ErrorReporter, or else the formatter registered as "console"
+func (er *ErrorReporter) format(reporter *Reporter) {
+	formatter := er.formatter
+	if formatter == nil {
+		var ok bool
+		if formatter, ok = LookupFormatter("console"); !ok {
+			formatter = ConsoleFormatter{}
+		}
+	}
+	if err := formatter.Format(er.writer, reporter.Report()); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to format report: %v\n", err)
+	}
+}
 
-		for _, failingType := range result.FailingTypes {
-			fmt.Fprintf(er.writer, "  - %s in package %s\n", failingType.Name, failingType.Package)
+// ConsoleFormatter writes the console summary of ErrorReporter, registered as "console".
+//
+// Results added without a pattern are reported only when they fail, or carry
+// warnings or exemptions. The rules of patterns follow, numbered, under the name
+// of the first pattern and grouped by nested pattern, with a summary of the
+// failures by severity and whether the codebase adheres to the pattern.
+type ConsoleFormatter struct{}
+
+// Format writes the report as a console summary
+func (ConsoleFormatter) Format(w io.Writer, report *Report) error {
+	var out strings.Builder
+
+	var rules []RuleReport
+	for _, rule := range report.Rules {
+		if rule.Pattern == "" {
+			writeConsoleResult(&out, rule)
+		} else {
+			rules = append(rules, rule)
 		}
 	}
+	if len(rules) > 0 {
+		writeConsolePattern(&out, rules)
+	}
 
-	fmt.Fprintln(er.writer)
+	_, err := io.WriteString(w, out.String())
+	return err
 }
 
-// ReportPatternValidation reports the results of validating an architectural pattern
-func (er *ErrorReporter) ReportPatternValidation(results []*ValidationResult) {
-	if len(results) == 0 {
+// writeConsoleResult writes a result added without a pattern
+func writeConsoleResult(out *strings.Builder, rule RuleReport) {
+	if rule.Passed {
+		writeConsoleNotes(out, rule)
 		return
 	}
 
-	patternName := results[0].PatternName
-	fmt.Fprintf(er.writer, "Validating %s Pattern\n", patternName)
-	fmt.Fprintf(er.writer, "%s\n", strings.Repeat("=", len(patternName)+18))
+	fmt.Fprintf(out, "Architecture Test Failed: %s\n", rule.Description)
+	if rule.Error != "" {
+		fmt.Fprintf(out, "Invalid rule: %s\n", rule.Error)
+	}
+	writeConsoleNotes(out, rule)
+	writeConsoleFailingTypes(out, rule.Violations)
+	out.WriteString("\n")
+}
+
+// writeConsolePattern writes the rules of a pattern, followed by the summary
+func writeConsolePattern(out *strings.Builder, rules []RuleReport) {
+	patternName := rules[0].Pattern
+	if len(rules[0].PatternPath) > 0 {
+		patternName = rules[0].PatternPath[0]
+	}
+	fmt.Fprintf(out, "Validating %s Pattern\n", patternName)
+	fmt.Fprintf(out, "%s\n", strings.Repeat("=", len(patternName)+18))
 
 	passCount := 0
-	failCount := 0
+	frozenCount := 0
+	failures := make(map[Severity]int)
+
+	currentPattern := patternName
+	for i, rule := range rules {
+		// Rules of nested patterns are introduced by the pattern path
+		if group := rule.group(); len(rule.PatternPath) > 1 && group != currentPattern {
+			currentPattern = group
+			fmt.Fprintf(out, "\n%s\n", group)
+		}
 
-	for i, result := range results {
-		if result.IsSuccessful {
+		frozenCount += rule.FrozenCount
+		if rule.Passed {
 			passCount++
-			fmt.Fprintf(er.writer, "Rule #%d: PASS\n", i+1)
-		} else {
-			failCount++
-			fmt.Fprintf(er.writer, "Rule #%d: FAIL\n", i+1)
+			fmt.Fprintf(out, "Rule #%d%s: PASS\n", i+1, rule.label())
+			writeConsoleNotes(out, rule)
+			continue
+		}
 
-			if len(result.FailingTypes) > 0 {
-				fmt.Fprintln(er.writer, "Failing Types:")
+		failures[rule.Severity.orDefault()]++
+		fmt.Fprintf(out, "Rule #%d%s: FAIL [%s]\n", i+1, rule.label(), rule.Severity.orDefault())
+		if rule.Description != "" {
+			fmt.Fprintf(out, "%s\n", rule.Description)
+		}
+		if rule.Error != "" {
+			fmt.Fprintf(out, "Invalid rule: %s\n", rule.Error)
+		}
+		writeConsoleNotes(out, rule)
+		writeConsoleFailingTypes(out, rule.Violations)
+		out.WriteString("\n")
+	}
 
-				for _, failingType := range result.FailingTypes {
-					fmt.Fprintf(er.writer, "  - %s in package %s\n", failingType.Name, failingType.Package)
-				}
-			}
+	fmt.Fprintf(out, "\nSummary: %d/%d rules passed\n", passCount, len(rules))
+	if passCount < len(rules) {
+		fmt.Fprintf(out, "Failures by severity: %d error, %d warning, %d info\n",
+			failures[SeverityError], failures[SeverityWarning], failures[SeverityInfo])
+	}
+	if frozenCount > 0 {
+		fmt.Fprintf(out, "Frozen violations remaining in baseline: %d\n", frozenCount)
+	}
 
-			fmt.Fprintln(er.writer)
-		}
+	switch {
+	case passCount == len(rules):
+		fmt.Fprintf(out, "The codebase adheres to the %s pattern.\n", patternName)
+	case failures[SeverityError] == 0:
+		fmt.Fprintf(out, "The codebase adheres to the %s pattern, with non-blocking findings.\n", patternName)
+	default:
+		fmt.Fprintf(out, "The codebase does NOT fully adhere to the %s pattern.\n", patternName)
 	}
 
-	fmt.Fprintf(er.writer, "\nSummary: %d/%d rules passed\n", passCount, len(results))
+	out.WriteString("\n")
+}
 
-	if failCount == 0 {
-		fmt.Fprintf(er.writer, "The codebase adheres to the %s pattern.\n", patternName)
-	} else {
-		fmt.Fprintf(er.writer, "The codebase does NOT fully adhere to the %s pattern.\n", patternName)
+// writeConsoleNotes writes the warnings, exemptions and frozen violations of a rule
+func writeConsoleNotes(out *strings.Builder, rule RuleReport) {
+	for _, warning := range rule.Warnings {
+		fmt.Fprintf(out, "Warning: %s\n", warning)
+	}
+	for _, exemption := range rule.Exemptions {
+		fmt.Fprintf(out, "Exempted: %s in package %s (%s)\n", exemption.Type, exemption.Package, exemption.Reason)
+	}
+	if rule.FrozenCount > 0 {
+		fmt.Fprintf(out, "Frozen: %d violation(s) in baseline\n", rule.FrozenCount)
 	}
+}
 
-	fmt.Fprintln(er.writer)
+// writeConsoleFailingTypes lists the types violating a rule, with the reasons for each
+func writeConsoleFailingTypes(out *strings.Builder, violations []ViolationReport) {
+	types := failingTypes(violations)
+	if len(types) == 0 {
+		return
+	}
+
+	out.WriteString("Failing Types:\n")
+	for _, t := range types {
+		fmt.Fprintf(out, "  - %s in package %s\n", t.Type, t.Package)
+		for _, reason := range t.Reasons {
+			fmt.Fprintf(out, "      %s\n", reason)
+		}
+	}
 }
 
 // GenerateDependencyGraph generates a dot graph representing dependencies
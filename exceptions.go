@@ -0,0 +1,195 @@
+package goarchtest
+
+import "fmt"
+
+// Exemption records a type that was excluded from a rule, together with the
+// documented reason for the exception
+type Exemption struct {
+	Type   *TypeInfo
+	Reason string
+}
+
+// exception is a pending exclusion on the condition side of a chain,
+// applied to the failing types when the result is evaluated
+type exception struct {
+	reason string
+	match  CustomPredicate
+}
+
+// Except excludes the types of another TypeSet from the rule.
+//
+// Like every exclusion method it works on both sides of a chain: before Should or
+// ShouldNot the types are removed from the selection; after them the types are
+// exempted from the condition and never reported as failing. The reason is required
+// and is echoed in the Result, so every exemption is documented.
+//
+// Parameters:
+//   - reason: Why the exception is legitimate
+//   - other: The types to exclude
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet itself to allow for method chaining
+//
+// Example:
+//
+//	legacy := types.That().HaveNameStartingWith("Legacy")
+//	result := types.That().
+//	    ResideInNamespace("presentation").
+//	    Except("legacy handlers are being migrated", legacy).
+//	    ShouldNot().
+//	    HaveDependencyOn("data").
+//	    GetResult()
+func (ts *TypeSet) Except(reason string, other *TypeSet) *TypeSet {
+	excluded := make(map[string]bool, len(other.types))
+	for _, t := range other.types {
+		excluded[typeKey(t)] = true
+	}
+
+	return ts.except("Except", fmt.Sprintf("types (%s)", other.Description()), reason, func(t *TypeInfo) bool {
+		return excluded[typeKey(t)]
+	})
+}
+
+// ExceptTypes excludes types by qualified name from the rule.
+// A name is either "package.Type" or "import/path.Type".
+// See Except for how exclusions apply to selections and conditions.
+//
+// Parameters:
+//   - reason: Why the exception is legitimate
+//   - names: The qualified names of the types to exclude
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet itself to allow for method chaining
+//
+// Example:
+//
+//	typeSet.ExceptTypes("generated by sqlc", "db.Queries", "db.DBTX")
+func (ts *TypeSet) ExceptTypes(reason string, names ...string) *TypeSet {
+	excluded := make(map[string]bool, len(names))
+	for _, name := range names {
+		excluded[name] = true
+	}
+
+	return ts.except("ExceptTypes", "types "+quoteAll(names), reason, func(t *TypeInfo) bool {
+		return excluded[t.Package+"."+t.Name] || excluded[typeKey(t)]
+	})
+}
+
+// ExceptNamespaces excludes types residing in any of the namespaces from the rule.
+// Namespaces are patterns understood by NamespaceMatcher.
+// See Except for how exclusions apply to selections and conditions.
+//
+// Parameters:
+//   - reason: Why the exception is legitimate
+//   - namespaces: The namespaces to exclude
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet itself to allow for method chaining
+//
+// Example:
+//
+//	typeSet.ExceptNamespaces("mocks are test helpers", "..mocks..")
+func (ts *TypeSet) ExceptNamespaces(reason string, namespaces ...string) *TypeSet {
+	ts.currentPredicate = "ExceptNamespaces"
+	matches := ts.pathMatcher(namespaces...)
+
+	return ts.except("ExceptNamespaces", "namespaces "+quoteAll(namespaces), reason, func(t *TypeInfo) bool {
+		return matches(t.FullPath)
+	})
+}
+
+// ExceptWhere excludes types matching a custom predicate from the rule.
+// See Except for how exclusions apply to selections and conditions.
+//
+// Parameters:
+//   - reason: Why the exception is legitimate
+//   - predicate: Returns true for types to exclude
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet itself to allow for method chaining
+//
+// Example:
+//
+//	typeSet.ExceptWhere("DTOs may be shared", func(t *goarchtest.TypeInfo) bool {
+//	    return strings.HasSuffix(t.Name, "DTO")
+//	})
+func (ts *TypeSet) ExceptWhere(reason string, predicate CustomPredicate) *TypeSet {
+	return ts.except("ExceptWhere", "types matching a custom predicate", reason, predicate)
+}
+
+// except applies an exclusion: immediately on the selection side of the chain,
+// or deferred to GetResult on the condition side
+func (ts *TypeSet) except(predicate, subject, reason string, match CustomPredicate) *TypeSet {
+	ts.currentPredicate = predicate
+
+	if reason == "" {
+		ts.recordError(fmt.Errorf("%s requires a reason for the exception", predicate))
+	}
+
+	ts.phrases = append(ts.phrases, fmt.Sprintf("except %s (%s)", subject, reason))
+
+	if ts.conditioned {
+		ts.exceptions = append(ts.exceptions, exception{reason: reason, match: match})
+		return ts
+	}
+
+	var filteredTypes []*TypeInfo
+	for _, t := range ts.types {
+		if match(t) {
+			ts.exemptions = append(ts.exemptions, Exemption{Type: t, Reason: reason})
+			continue
+		}
+		filteredTypes = append(filteredTypes, t)
+	}
+
+	ts.types = filteredTypes
+	return ts
+}
+
+// exempt removes the types covered by condition-side exceptions from the failing types
+func (ts *TypeSet) exempt(failingTypes []*TypeInfo) ([]*TypeInfo, []Exemption) {
+	if len(ts.exceptions) == 0 {
+		return failingTypes, nil
+	}
+
+	var remaining []*TypeInfo
+	var exemptions []Exemption
+	for _, t := range failingTypes {
+		if reason, ok := ts.exceptionFor(t); ok {
+			exemptions = append(exemptions, Exemption{Type: t, Reason: reason})
+			continue
+		}
+		remaining = append(remaining, t)
+	}
+
+	return remaining, exemptions
+}
+
+// exemptedCount counts the selected types covered by condition-side exceptions
+func (ts *TypeSet) exemptedCount() int {
+	count := 0
+	for _, t := range ts.selected {
+		if _, ok := ts.exceptionFor(t); ok {
+			count++
+		}
+	}
+	return count
+}
+
+// exceptionFor returns the reason of the first condition-side exception covering the type
+func (ts *TypeSet) exceptionFor(t *TypeInfo) (string, bool) {
+	for _, e := range ts.exceptions {
+		if e.match(t) {
+			return e.reason, true
+		}
+	}
+	return "", false
+}
+
+// typeKey identifies a type by import path and name, or is empty for no type
+func typeKey(t *TypeInfo) string {
+	if t == nil {
+		return ""
+	}
+	return t.FullPath + "." + t.Name
+}
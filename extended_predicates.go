@@ -1,6 +1,7 @@
 package goarchtest
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -36,6 +37,7 @@ func (ts *TypeSet) NameMatch(pattern string) *TypeSet {
 	}
 
 	ts.types = filteredTypes
+	ts.describe(fmt.Sprintf("have name matching '%s'", pattern))
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
@@ -63,6 +65,7 @@ func (ts *TypeSet) HaveNameEndingWith(suffix string) *TypeSet {
 	}
 
 	ts.types = filteredTypes
+	ts.describe(fmt.Sprintf("have name ending with '%s'", suffix))
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
@@ -90,12 +93,14 @@ func (ts *TypeSet) HaveNameStartingWith(prefix string) *TypeSet {
 	}
 
 	ts.types = filteredTypes
+	ts.describe(fmt.Sprintf("have name starting with '%s'", prefix))
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
 
 // ResideInDirectory filters types that reside in the specified directory
 // It allows for filtering based on the directory structure of the type's full path.
+// The directory is matched as whole path segments, like a ResideInNamespace pattern.
 // Parameters:
 //   - directory: A string representing the directory to match against type full paths
 //
@@ -109,25 +114,29 @@ func (ts *TypeSet) HaveNameStartingWith(prefix string) *TypeSet {
 func (ts *TypeSet) ResideInDirectory(directory string) *TypeSet {
 	ts.currentPredicate = "ResideInDirectory"
 
+	matches := ts.pathMatcher(directory)
+
 	var filteredTypes []*TypeInfo
 	for _, t := range ts.types {
-		if strings.Contains(t.FullPath, directory) {
+		if matches(t.FullPath) {
 			filteredTypes = append(filteredTypes, t)
 		}
 	}
 
 	ts.types = filteredTypes
+	ts.describe(fmt.Sprintf("reside in directory '%s'", directory))
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
 
 // DoNotResideInNamespace filters types that do not reside in the specified namespace
 // It allows for excluding types based on their package namespace.
+// The namespace is matched against the full import path, exactly as ResideInNamespace does.
 // Parameters:
-//   - namespace: A string representing the namespace to exclude from the type's package
+//   - namespace: A string representing the namespace pattern to exclude
 //
 // Returns:
-//   - *TypeSet: Returns the filtered TypeSet containing only types whose packages do not contain the namespace,
+//   - *TypeSet: Returns the filtered TypeSet containing only types whose packages do not match the namespace,
 //     allowing for method chaining
 //
 // Example:
@@ -136,24 +145,28 @@ func (ts *TypeSet) ResideInDirectory(directory string) *TypeSet {
 func (ts *TypeSet) DoNotResideInNamespace(namespace string) *TypeSet {
 	ts.currentPredicate = "DoNotResideInNamespace"
 
+	matches := ts.pathMatcher(namespace)
+
 	var filteredTypes []*TypeInfo
 	for _, t := range ts.types {
-		if !strings.Contains(t.Package, namespace) {
+		if !matches(t.FullPath) {
 			filteredTypes = append(filteredTypes, t)
 		}
 	}
 
 	ts.types = filteredTypes
+	ts.describe(fmt.Sprintf("do not reside in namespace '%s'", namespace))
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
 
 // DoNotHaveDependencyOn filters the TypeSet to include only types that do not have
 // a dependency on the specified import path. A type is considered to not have the
-// dependency if none of its imports contain the given dependency string.
+// dependency if none of its imports match the dependency pattern, using the same
+// matching as HaveDependencyOn.
 //
 // Parameters:
-//   - dependency: A string representing the import path or part of it to check against
+//   - dependency: A string representing the import path pattern to check against
 //
 // Returns:
 //   - *TypeSet: Returns the filtered TypeSet containing only types without the specified dependency,
@@ -165,11 +178,13 @@ func (ts *TypeSet) DoNotResideInNamespace(namespace string) *TypeSet {
 func (ts *TypeSet) DoNotHaveDependencyOn(dependency string) *TypeSet {
 	ts.currentPredicate = "DoNotHaveDependencyOn"
 
+	matches := ts.pathMatcher(dependency)
+
 	var filteredTypes []*TypeInfo
 	for _, t := range ts.types {
 		hasDependency := false
 		for _, imp := range t.Imports {
-			if strings.Contains(imp, dependency) {
+			if matches(imp) {
 				hasDependency = true
 				break
 			}
@@ -181,6 +196,7 @@ func (ts *TypeSet) DoNotHaveDependencyOn(dependency string) *TypeSet {
 	}
 
 	ts.types = filteredTypes
+	ts.describe(fmt.Sprintf("do not have dependency on '%s'", dependency))
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
@@ -223,6 +239,7 @@ func (ts *TypeSet) AreInterfaces() *TypeSet {
 	}
 
 	ts.types = filteredTypes
+	ts.describe("be interfaces")
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
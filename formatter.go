@@ -0,0 +1,83 @@
+package goarchtest
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Formatter writes a Report in a given format.
+// Formatters registered with RegisterFormatter can be used by name with
+// Reporter.SaveReport and Reporter.WriteReport, and any formatter can be used
+// by an ErrorReporter.
+type Formatter interface {
+	Format(w io.Writer, report *Report) error
+}
+
+// FormatterFunc adapts a function to the Formatter interface
+type FormatterFunc func(w io.Writer, report *Report) error
+
+// Format calls f(w, report)
+func (f FormatterFunc) Format(w io.Writer, report *Report) error {
+	return f(w, report)
+}
+
+// formatters holds the formatters by lowercase name, starting with the built-in ones
+var formatters = struct {
+	sync.RWMutex
+	byName map[string]Formatter
+}{byName: map[string]Formatter{
+	"text":     TextFormatter{},
+	"console":  ConsoleFormatter{},
+	"html":     HTMLFormatter{},
+	"markdown": MarkdownFormatter{},
+	"md":       MarkdownFormatter{},
+	"json":     JSONFormatter{},
+	"junit":    JUnitFormatter{},
+	"sarif":    SARIFFormatter{},
+}}
+
+// RegisterFormatter registers a formatter under a name, so that reports can be
+// saved in its format with SaveReport. Names are case-insensitive. Registering
+// a name again replaces the previous formatter, including built-in ones:
+// "text", "console", "html", "markdown" (or "md"), "json", "junit" and "sarif".
+//
+// Example:
+//
+//	goarchtest.RegisterFormatter("csv", goarchtest.FormatterFunc(func(w io.Writer, report *goarchtest.Report) error {
+//	    for _, rule := range report.Rules {
+//	        if _, err := fmt.Fprintf(w, "%s,%t,%d\n", rule.ID, rule.Passed, len(rule.Violations)); err != nil {
+//	            return err
+//	        }
+//	    }
+//	    return nil
+//	}))
+//
+//	err := reporter.SaveReport("csv", "architecture.csv")
+func RegisterFormatter(name string, formatter Formatter) {
+	formatters.Lock()
+	defer formatters.Unlock()
+	formatters.byName[strings.ToLower(name)] = formatter
+}
+
+// LookupFormatter returns the formatter registered under a name
+func LookupFormatter(name string) (Formatter, bool) {
+	formatters.RLock()
+	defer formatters.RUnlock()
+	formatter, ok := formatters.byName[strings.ToLower(name)]
+	return formatter, ok
+}
+
+// Formatters returns the names of all registered formatters, sorted
+func Formatters() []string {
+	formatters.RLock()
+	defer formatters.RUnlock()
+
+	var names []string
+	for name := range formatters.byName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
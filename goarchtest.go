@@ -68,9 +68,9 @@ type GoArchTest struct {
 }
 
 // New creates a new instance of GoArchTest for the specified path
-func New(path string) *GoArchTest {
+func New(path string, opts ...Option) *GoArchTest {
 	return &GoArchTest{
-		Types: InPath(path),
+		Types: InPath(path, opts...),
 	}
 }
 
@@ -0,0 +1,145 @@
+package goarchtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the rules of one pattern
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is the outcome of one rule
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitFailure describes why a test case failed
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// GenerateJUnitReport generates a JUnit XML report of the architecture test results,
+// so that CI systems show architecture rules next to unit tests.
+//
+// Every pattern becomes a testsuite, named from its pattern path, and every rule
+// a testcase, named from its ID or else its description; results added with
+// AddResult are grouped in an "Architecture Rules" suite. Failed rules carry
+// their violations with file positions. As in the archtesting helpers, only
+// rules with error severity fail: violations of warning and info rules are
+// written to the testcase output. Invalid rules are reported as errors.
+func (r *Reporter) GenerateJUnitReport() (string, error) {
+	return r.render(JUnitFormatter{})
+}
+
+// JUnitFormatter writes reports as JUnit XML, registered as "junit".
+// See Reporter.GenerateJUnitReport for how rules are mapped.
+type JUnitFormatter struct{}
+
+// Format writes the report as JUnit XML
+func (JUnitFormatter) Format(w io.Writer, report *Report) error {
+	data, err := xml.MarshalIndent(report.junit(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, xml.Header+string(data)+"\n")
+	return err
+}
+
+// junit converts the report to JUnit test suites, in the order of the rules
+func (report *Report) junit() junitTestSuites {
+	suites := junitTestSuites{Name: "goarchtest"}
+	index := make(map[string]int)
+
+	for _, rule := range report.Rules {
+		name := rule.group()
+		i, ok := index[name]
+		if !ok {
+			i = len(suites.Suites)
+			index[name] = i
+			suites.Suites = append(suites.Suites, junitTestSuite{
+				Name:      name,
+				Timestamp: report.Metadata.GeneratedAt.Format(time.RFC3339),
+			})
+		}
+
+		testCase := junitTestCase{Name: rule.ID, Classname: name}
+		if testCase.Name == "" {
+			testCase.Name = rule.Description
+		}
+
+		var output []string
+		switch {
+		case rule.Error != "":
+			testCase.Error = &junitFailure{Message: rule.Error, Type: "InvalidRule", Text: rule.Description}
+			suites.Suites[i].Errors++
+			suites.Errors++
+		case !rule.Passed && rule.Severity.orDefault() == SeverityError:
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%s: %d violation(s)", rule.Description, len(rule.Violations)),
+				Type:    "ArchitectureViolation",
+				Text:    junitViolations(rule.Violations),
+			}
+			suites.Suites[i].Failures++
+			suites.Failures++
+		case !rule.Passed:
+			output = append(output, fmt.Sprintf("%s rule failed: %s", rule.Severity, rule.Description))
+			output = append(output, junitViolations(rule.Violations))
+		}
+
+		for _, warning := range rule.Warnings {
+			output = append(output, "Warning: "+warning)
+		}
+		for _, exemption := range rule.Exemptions {
+			output = append(output, fmt.Sprintf("Exempted: %s in package %s (%s)", exemption.Type, exemption.Package, exemption.Reason))
+		}
+		testCase.SystemOut = strings.Join(output, "\n")
+
+		suites.Suites[i].Cases = append(suites.Suites[i].Cases, testCase)
+		suites.Suites[i].Tests++
+		suites.Tests++
+	}
+
+	return suites
+}
+
+// junitViolations lists violations, one per line, prefixed with their position
+func junitViolations(violations []ViolationReport) string {
+	var lines []string
+	for _, v := range violations {
+		line := fmt.Sprintf("%s in package %s", v.Type, v.Package)
+		if v.Position != nil {
+			line = v.Position.String() + ": " + line
+		}
+		if v.Reason != "" {
+			line += ": " + v.Reason
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,109 @@
+package goarchtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Progress describes how far loading a model has come.
+//
+// Fields:
+//   - Loaded: The number of packages loaded by the go command
+//   - Analyzed: The number of loaded packages whose types were extracted into the model
+type Progress struct {
+	Loaded   int
+	Analyzed int
+}
+
+// WithProgress registers a callback receiving the progress of the load: once
+// when the packages are loaded, then after every analyzed package.
+// The callback is called from the loading goroutine.
+//
+// Example:
+//
+//	types, err := goarchtest.LoadContext(ctx, "./", goarchtest.WithProgress(func(p goarchtest.Progress) {
+//	    log.Printf("analyzed %d/%d packages", p.Analyzed, p.Loaded)
+//	}))
+func WithProgress(callback func(Progress)) Option {
+	return func(c *config) {
+		c.progress = callback
+	}
+}
+
+// WithLoadTimeout bounds how long LoadContext and InPath may take to load the
+// packages. When the timeout expires, loading stops, including a hung go command,
+// and LoadContext returns an error wrapping context.DeadlineExceeded.
+//
+// Example:
+//
+//	types, err := goarchtest.LoadContext(ctx, "./", goarchtest.WithLoadTimeout(2*time.Minute))
+func WithLoadTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.loadTimeout = timeout
+	}
+}
+
+// reportProgress passes the progress to the registered callback, if any
+func (c *config) reportProgress(progress Progress) {
+	if c.progress != nil {
+		c.progress(progress)
+	}
+}
+
+// LoadContext creates a new Types instance for packages in the specified directory
+// path, like InPath, under the control of a context.
+//
+// The context is passed to the go command and to the type-checker: cancelling it,
+// or exceeding its deadline or the WithLoadTimeout timeout, stops loading.
+// Unlike InPath, LoadContext returns loading errors instead of an empty model.
+//
+// Example:
+//
+//	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer cancel()
+//
+//	types, err := goarchtest.LoadContext(ctx, "./", goarchtest.WithLoadTimeout(5*time.Minute))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func LoadContext(ctx context.Context, path string, opts ...Option) (*Types, error) {
+	types, err := loadWithin(ctx, path, newConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	types.path, types.opts = path, opts
+	return types, nil
+}
+
+// loadWithin loads the model of the packages in path, within the load timeout
+func loadWithin(ctx context.Context, path string, options *config) (*Types, error) {
+	if options.loadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.loadTimeout)
+		defer cancel()
+	}
+
+	types, err := loadTypes(ctx, path, options)
+	if err != nil {
+		return nil, loadError(ctx, path, options, err)
+	}
+	return types, nil
+}
+
+// loadError explains a loading failure caused by the context
+func loadError(ctx context.Context, path string, options *config, err error) error {
+	switch cause := ctx.Err(); {
+	case errors.Is(cause, context.DeadlineExceeded) && options.loadTimeout > 0:
+		return fmt.Errorf("loading packages in %s timed out after %s; increase WithLoadTimeout or check that the go command can list the module: %w",
+			path, options.loadTimeout, cause)
+	case errors.Is(cause, context.DeadlineExceeded):
+		return fmt.Errorf("loading packages in %s did not finish before the context deadline: %w", path, cause)
+	case errors.Is(cause, context.Canceled):
+		return fmt.Errorf("loading packages in %s was canceled: %w", path, cause)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,230 @@
+package goarchtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LoadMode selects how deeply the packages are analyzed when the model is loaded
+type LoadMode int
+
+const (
+	// LoadFull type-checks the packages, so that rules can use go/types
+	// information through PredicateContext. It is the default.
+	LoadFull LoadMode = iota
+	// LoadImports builds the model from the imports and the parsed declarations
+	// only, without type-checking. Dependency, naming and structural rules work
+	// as usual; rules that need type information fail with ErrTypeInfoUnavailable.
+	LoadImports
+)
+
+// ErrTypeInfoUnavailable is reported in Result.Err by rules that need go/types
+// information, such as the built-in "entity" predicate, when the model was
+// loaded with LoadImports
+var ErrTypeInfoUnavailable = errors.New("type information is not loaded")
+
+// ErrCacheRequiresLoadImports is returned when the model cache is enabled without
+// LoadImports: a cached model carries no go/types information, so the cache
+// cannot serve the full load mode
+var ErrCacheRequiresLoadImports = errors.New("the model cache requires WithLoadMode(LoadImports)")
+
+// WithLoadMode sets how deeply the packages are analyzed.
+// LoadImports skips type-checking and the loading of dependencies, which
+// makes loading much faster for suites made of dependency rules.
+//
+// Example:
+//
+//	types := goarchtest.InPath("./", goarchtest.WithLoadMode(goarchtest.LoadImports))
+func WithLoadMode(mode LoadMode) Option {
+	return func(c *config) {
+		c.loadMode = mode
+	}
+}
+
+// loadImportsOnly loads the model of the packages in path without type-checking
+// them, through the model cache when it is enabled
+func loadImportsOnly(ctx context.Context, path string, cfg *config) (*Types, error) {
+	if cfg.cache {
+		types, err := loadCachedModel(ctx, path, cfg)
+		if err == nil || ctx.Err() != nil {
+			return types, err
+		}
+		fmt.Fprintf(os.Stderr, "Failed to use the model cache, loading without it: %v\n", err)
+	}
+
+	snapshot, err := listPackages(ctx, path, cfg, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.model(cfg, CacheStats{}), nil
+}
+
+// listPackages lists the packages in path and parses their declarations.
+// When a previous snapshot is given, packages whose files did not change are
+// taken from it instead of being parsed, and the counts are added to the stats.
+func listPackages(ctx context.Context, path string, cfg *config, previous *modelSnapshot, stats *CacheStats) (*modelSnapshot, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:       packages.NeedName | packages.NeedFiles | packages.NeedImports,
+		Context:    ctx,
+		Dir:        path,
+		BuildFlags: cfg.buildFlags,
+		Overlay:    cfg.overlay,
+	}, "./...")
+	if err != nil {
+		return nil, err
+	}
+	cfg.reportProgress(Progress{Loaded: len(pkgs)})
+
+	cached := make(map[string]*packageSnapshot)
+	if previous != nil {
+		for _, pkg := range previous.Packages {
+			cached[pkg.Path] = pkg
+		}
+	}
+
+	current := &modelSnapshot{Format: cacheFormat}
+	for i, pkg := range pkgs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		snapshot := &packageSnapshot{Path: pkg.PkgPath, Name: pkg.Name}
+		for importPath := range pkg.Imports {
+			snapshot.Imports = append(snapshot.Imports, importPath)
+		}
+		sort.Strings(snapshot.Imports)
+
+		// Packages with errors contribute their imports but no types,
+		// as when the packages are type-checked
+		if len(pkg.Errors) > 0 {
+			for _, err := range pkg.Errors {
+				snapshot.Errors = append(snapshot.Errors, err.Error())
+			}
+			current.Packages = append(current.Packages, snapshot)
+			cfg.reportProgress(Progress{Loaded: len(pkgs), Analyzed: i + 1})
+			continue
+		}
+
+		var hash string
+		if previous != nil {
+			if hash, err = hashFiles(pkg.GoFiles); err != nil {
+				return nil, err
+			}
+		}
+
+		if entry, ok := cached[pkg.PkgPath]; ok && entry.Hash == hash {
+			snapshot.Hash, snapshot.Types, snapshot.ImportPositions = hash, entry.Types, entry.ImportPositions
+			if stats != nil {
+				stats.Hits++
+			}
+		} else if err := snapshot.analyze(pkg, cfg.overlay); err != nil {
+			snapshot.Errors = append(snapshot.Errors, err.Error())
+		} else {
+			snapshot.Hash = hash
+			if stats != nil {
+				stats.Misses++
+			}
+		}
+
+		current.Packages = append(current.Packages, snapshot)
+		cfg.reportProgress(Progress{Loaded: len(pkgs), Analyzed: i + 1})
+	}
+
+	return current, nil
+}
+
+// model rebuilds the model from the snapshot.
+// The model has no loaded packages, hence no go/types information; the errors
+// of the packages are kept for packageErrors.
+func (snapshot *modelSnapshot) model(cfg *config, stats CacheStats) *Types {
+	var types []*TypeInfo
+	interned := make(map[string]string)
+	importers := make(map[string][]string)
+	var loadErrors []error
+
+	for _, pkg := range snapshot.Packages {
+		for _, err := range pkg.Errors {
+			loadErrors = append(loadErrors, fmt.Errorf("%s: %s", pkg.Path, err))
+		}
+
+		imports := make([]string, len(pkg.Imports))
+		for i, importPath := range pkg.Imports {
+			if existing, ok := interned[importPath]; ok {
+				importPath = existing
+			} else {
+				interned[importPath] = importPath
+			}
+			imports[i] = importPath
+			importers[importPath] = append(importers[importPath], pkg.Path)
+		}
+
+		for _, t := range pkg.Types {
+			types = append(types, &TypeInfo{
+				Name:            t.Name,
+				Package:         pkg.Name,
+				FullPath:        pkg.Path,
+				Imports:         imports,
+				Interfaces:      t.Interfaces,
+				IsStruct:        t.IsStruct,
+				IsInterface:     t.IsInterface,
+				Position:        t.Position,
+				importPositions: pkg.ImportPositions,
+			})
+		}
+	}
+	numberTypes(types)
+
+	for _, list := range importers {
+		sort.Strings(list)
+	}
+
+	return &Types{
+		packages:   map[string]*packages.Package{},
+		typeSet:    &TypeSet{types: types, originalTypes: types},
+		importers:  importers,
+		index:      newModelIndex(types),
+		config:     cfg,
+		cache:      stats,
+		loadErrors: loadErrors,
+	}
+}
+
+// analyze parses the files of a package, read from the overlay when they are
+// part of it, and extracts its types and the positions of its imports
+func (snapshot *packageSnapshot) analyze(pkg *packages.Package, overlay map[string][]byte) error {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(pkg.GoFiles))
+	for _, name := range pkg.GoFiles {
+		var src any
+		if content, ok := overlay[name]; ok {
+			src = content
+		}
+
+		file, err := parser.ParseFile(fset, name, src, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+	}
+
+	types := extractTypes(pkg, fset, nil, files)
+	for _, t := range types {
+		snapshot.ImportPositions = t.importPositions
+		snapshot.Types = append(snapshot.Types, typeSnapshot{
+			Name:        t.Name,
+			Interfaces:  t.Interfaces,
+			IsStruct:    t.IsStruct,
+			IsInterface: t.IsInterface,
+			Position:    t.Position,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package goarchtest
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GitHubCommentLimit is the maximum length of a GitHub pull request comment,
+// to be passed to GenerateMarkdownReportWithLimit
+const GitHubCommentLimit = 65536
+
+// GenerateMarkdownReport generates a Markdown report of the architecture test
+// results, suited to pull request comments.
+//
+// The report starts with a summary table of the passed and failed rules of
+// every pattern, followed by a collapsible <details> section per failing rule
+// with a table of its violations: the type, its file:line position and the
+// evidence. Passing rules are only counted.
+//
+// Example:
+//
+//	reporter.AddValidationResults(cleanArch.Validate(types))
+//	comment := reporter.GenerateMarkdownReport()
+func (r *Reporter) GenerateMarkdownReport() string {
+	return r.GenerateMarkdownReportWithLimit(0)
+}
+
+// GenerateMarkdownReportWithLimit generates the Markdown report of
+// GenerateMarkdownReport, truncated to at most limit bytes, which is never
+// fewer than the characters counted by comment hosts.
+// The summary table is always kept. Failing rules are added in order as long as
+// they fit, the last one with as many violations as fit, and a closing note
+// tells how many violations and rules were left out. A limit of zero or less
+// disables truncation.
+//
+// Example:
+//
+//	comment := reporter.GenerateMarkdownReportWithLimit(goarchtest.GitHubCommentLimit)
+func (r *Reporter) GenerateMarkdownReportWithLimit(limit int) string {
+	markdown, _ := r.render(MarkdownFormatter{Limit: limit})
+	return markdown
+}
+
+// MarkdownFormatter writes reports as Markdown, registered as "markdown" and "md".
+// Reports longer than Limit bytes are truncated as described in
+// Reporter.GenerateMarkdownReportWithLimit; a Limit of zero disables truncation.
+//
+// Example:
+//
+//	goarchtest.RegisterFormatter("comment", goarchtest.MarkdownFormatter{Limit: goarchtest.GitHubCommentLimit})
+type MarkdownFormatter struct {
+	Limit int
+}
+
+// Format writes the report as Markdown
+func (f MarkdownFormatter) Format(w io.Writer, report *Report) error {
+	_, err := io.WriteString(w, report.markdown(f.Limit))
+	return err
+}
+
+// markdown renders the report as Markdown, truncated to limit bytes if limit is positive
+func (report *Report) markdown(limit int) string {
+	var failing []RuleReport
+	for _, rule := range report.Rules {
+		if !rule.Passed {
+			failing = append(failing, rule)
+		}
+	}
+
+	var out strings.Builder
+	report.writeMarkdownSummary(&out)
+	if len(failing) > 0 {
+		out.WriteString("### Failing rules\n\n")
+	}
+
+	summary := out.String()
+	for _, rule := range failing {
+		out.WriteString(markdownRuleSection(rule, len(rule.Violations)))
+	}
+	if limit <= 0 || out.Len() <= limit {
+		return out.String()
+	}
+	out.Reset()
+	out.WriteString(summary)
+
+	// Room is kept for the note on omitted content, at its longest
+	budget := limit - len(markdownOmitted(len(failing), countViolations(failing)))
+	if out.Len() > budget {
+		summary := truncateMarkdown(out.String(), budget) + markdownOmitted(len(failing), countViolations(failing))
+		return truncateMarkdown(summary, limit)
+	}
+
+	omittedViolations := 0
+	for i, rule := range failing {
+		section := markdownRuleSection(rule, len(rule.Violations))
+		if out.Len()+len(section) <= budget {
+			out.WriteString(section)
+			continue
+		}
+
+		// Keep as many violations of the first rule that does not fit as possible
+		fits := sort.Search(len(rule.Violations)+1, func(n int) bool {
+			return out.Len()+len(markdownRuleSection(rule, n)) > budget
+		}) - 1
+		if fits >= 0 {
+			out.WriteString(markdownRuleSection(rule, fits))
+			omittedViolations += len(rule.Violations) - fits
+			i++
+		}
+		for _, omitted := range failing[i:] {
+			omittedViolations += len(omitted.Violations)
+		}
+		out.WriteString(markdownOmitted(len(failing)-i, omittedViolations))
+		break
+	}
+
+	return out.String()
+}
+
+// writeMarkdownSummary writes the title, the overall outcome and the table of
+// passed and failed rules per pattern, in the order the patterns first appear
+func (report *Report) writeMarkdownSummary(out *strings.Builder) {
+	type counts struct{ passed, failed int }
+	var groups []string
+	byGroup := make(map[string]*counts)
+	for _, rule := range report.Rules {
+		group := rule.group()
+		if byGroup[group] == nil {
+			byGroup[group] = &counts{}
+			groups = append(groups, group)
+		}
+		if rule.Passed {
+			byGroup[group].passed++
+		} else {
+			byGroup[group].failed++
+		}
+	}
+
+	out.WriteString("## GoArchTest Report\n\n")
+	if report.Summary.Failed == 0 {
+		fmt.Fprintf(out, "**All %d rules passed.**\n\n", report.Summary.Total)
+	} else {
+		fmt.Fprintf(out, "**%d of %d rules failed.**\n\n", report.Summary.Failed, report.Summary.Total)
+	}
+	if len(groups) == 0 {
+		return
+	}
+
+	out.WriteString("| Pattern | Rules | Passed | Failed |\n")
+	out.WriteString("| --- | ---: | ---: | ---: |\n")
+	for _, group := range groups {
+		c := byGroup[group]
+		fmt.Fprintf(out, "| %s | %d | %d | %d |\n", markdownCell(group), c.passed+c.failed, c.passed, c.failed)
+	}
+	out.WriteString("\n")
+}
+
+// markdownRuleSection renders the collapsible section of a failing rule,
+// listing its first n violations
+func markdownRuleSection(rule RuleReport, n int) string {
+	var out strings.Builder
+
+	out.WriteString("<details>\n<summary>")
+	if rule.ID != "" {
+		fmt.Fprintf(&out, "<code>%s</code>: ", html.EscapeString(rule.ID))
+	}
+	fmt.Fprintf(&out, "%s (%s", html.EscapeString(rule.Description), rule.Severity.orDefault())
+	if rule.Error == "" {
+		fmt.Fprintf(&out, ", %d violation(s)", len(rule.Violations))
+	}
+	out.WriteString(")</summary>\n\n")
+
+	if rule.Pattern != "" {
+		fmt.Fprintf(&out, "Pattern: %s\n\n", markdownText(rule.group()))
+	}
+	if rule.Error != "" {
+		fmt.Fprintf(&out, "Invalid rule: %s\n\n", markdownText(rule.Error))
+	}
+	if rule.Rationale != "" {
+		fmt.Fprintf(&out, "> %s\n\n", markdownText(rule.Rationale))
+	}
+	for _, warning := range rule.Warnings {
+		fmt.Fprintf(&out, "Warning: %s\n\n", markdownText(warning))
+	}
+
+	if len(rule.Violations) > 0 {
+		out.WriteString("| Type | Location | Evidence |\n")
+		out.WriteString("| --- | --- | --- |\n")
+		for _, v := range rule.Violations[:n] {
+			location := "-"
+			if v.Position != nil {
+				location = v.Position.String()
+			}
+			evidence := v.Evidence
+			if evidence == "" {
+				evidence = v.Reason
+			}
+			fmt.Fprintf(&out, "| %s | %s | %s |\n", markdownCode(v.Package+"."+v.Type), markdownCode(location), markdownCell(evidence))
+		}
+		if n < len(rule.Violations) {
+			fmt.Fprintf(&out, "| _%d more violation(s) omitted_ | | |\n", len(rule.Violations)-n)
+		}
+		out.WriteString("\n")
+	}
+
+	if rule.FrozenCount > 0 {
+		fmt.Fprintf(&out, "%d frozen violation(s) not shown.\n\n", rule.FrozenCount)
+	}
+	if len(rule.DocLinks) > 0 {
+		out.WriteString("See:")
+		for _, link := range rule.DocLinks {
+			fmt.Fprintf(&out, " <%s>", link)
+		}
+		out.WriteString("\n\n")
+	}
+
+	out.WriteString("</details>\n\n")
+	return out.String()
+}
+
+// markdownOmitted notes the failing rules and violations left out of a truncated report
+func markdownOmitted(rules, violations int) string {
+	return fmt.Sprintf("_Report truncated: %d more failing rule(s) and %d violation(s) omitted. See the full report for details._\n", rules, violations)
+}
+
+// countViolations returns the total number of violations of the rules
+func countViolations(rules []RuleReport) int {
+	total := 0
+	for _, rule := range rules {
+		total += len(rule.Violations)
+	}
+	return total
+}
+
+// truncateMarkdown cuts the Markdown at the last line break that fits in limit bytes
+func truncateMarkdown(markdown string, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	if len(markdown) <= limit {
+		return markdown
+	}
+	if i := strings.LastIndexByte(markdown[:limit], '\n'); i >= 0 {
+		return markdown[:i+1]
+	}
+	return ""
+}
+
+// markdownText makes text safe to embed in a Markdown paragraph within HTML
+func markdownText(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", " ")
+}
+
+// markdownCell makes text safe to embed in a Markdown table cell
+func markdownCell(text string) string {
+	return strings.ReplaceAll(markdownText(text), "|", "\\|")
+}
+
+// markdownCode renders text as inline code in a Markdown table cell
+func markdownCode(text string) string {
+	if strings.Contains(text, "`") {
+		return markdownCell(text)
+	}
+	return "`" + strings.ReplaceAll(text, "|", "\\|") + "`"
+}
@@ -0,0 +1,287 @@
+package goarchtest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// cacheFormat is bumped whenever the layout of the cached model changes,
+// so that caches written by other versions are ignored
+const cacheFormat = 3
+
+// CacheStats describes how a model was loaded through the model cache.
+//
+// Fields:
+//   - Enabled: true if the model was loaded through the cache
+//   - Path: The cache file of the analyzed module
+//   - Hits: The number of packages restored from the cache
+//   - Misses: The number of new or changed packages that were re-analyzed
+type CacheStats struct {
+	Enabled bool
+	Path    string
+	Hits    int
+	Misses  int
+}
+
+// WithCache stores the analyzed model on disk, under the "goarchtest" directory
+// of the user cache directory, and restores it on the next runs.
+//
+// The cache is keyed by the module, the Go version, the build flags and
+// environment, and the content of every analyzed file. An unchanged module is
+// restored without invoking the go command, and only the packages whose files
+// changed since the last run are re-analyzed, so repeated runs take milliseconds.
+//
+// A cached model carries no go/types information, so the cache must be
+// combined with WithLoadMode(LoadImports); with the full load mode, loading
+// fails with ErrCacheRequiresLoadImports rather than losing the type
+// information that predicates such as "entity" and "valueObject" rely on.
+//
+// Example:
+//
+//	types := goarchtest.InPath("./", goarchtest.WithCache(), goarchtest.WithLoadMode(goarchtest.LoadImports))
+func WithCache() Option {
+	return func(c *config) {
+		c.cache = true
+	}
+}
+
+// WithCacheDir enables the model cache, like WithCache, storing it in the given directory.
+//
+// Example:
+//
+//	types := goarchtest.InPath("./", goarchtest.WithCacheDir(".cache/goarchtest"), goarchtest.WithLoadMode(goarchtest.LoadImports))
+func WithCacheDir(dir string) Option {
+	return func(c *config) {
+		c.cache = true
+		c.cacheDir = dir
+	}
+}
+
+// CacheStats reports how the model was loaded through the cache.
+// Enabled is false when the model was loaded without it.
+func (t *Types) CacheStats() CacheStats {
+	return t.cache
+}
+
+// modelSnapshot is the serialized model of a module.
+// Fingerprint hashes every file that can change the model, so that an
+// unchanged module is restored without listing its packages.
+type modelSnapshot struct {
+	Format      int
+	Fingerprint string
+	Packages    []*packageSnapshot
+}
+
+// packageSnapshot holds a package and its types, along with the hash of the
+// files they were extracted from. Packages with errors have no hash, so they
+// are analyzed again on every run.
+type packageSnapshot struct {
+	Path            string
+	Name            string
+	Hash            string
+	Imports         []string
+	ImportPositions map[string][]Position
+	Types           []typeSnapshot
+	Errors          []string
+}
+
+// typeSnapshot holds the fields of a TypeInfo that are not shared by its package
+type typeSnapshot struct {
+	Name        string
+	Interfaces  []string
+	IsStruct    bool
+	IsInterface bool
+	Position    Position
+}
+
+// loadCachedModel loads the model of the module at path through the cache.
+// An unchanged module is restored as is. Otherwise the packages are listed, and
+// only the packages whose files changed are parsed again.
+func loadCachedModel(ctx context.Context, path string, cfg *config) (*Types, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cachePath, err := cacheFile(root, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fingerprint is computed before listing the packages, so that files
+	// changed meanwhile invalidate the cache on the next run
+	fingerprint, err := fingerprintModule(root)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := CacheStats{Enabled: true, Path: cachePath}
+	previous := readSnapshot(cachePath)
+	if previous.Fingerprint == fingerprint {
+		stats.Hits = len(previous.Packages)
+		cfg.reportProgress(Progress{Loaded: stats.Hits, Analyzed: stats.Hits})
+		return previous.model(cfg, stats), nil
+	}
+
+	current, err := listPackages(ctx, path, cfg, previous, &stats)
+	if err != nil {
+		return nil, err
+	}
+	current.Fingerprint = fingerprint
+
+	if err := writeSnapshot(cachePath, current); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write the model cache: %v\n", err)
+	}
+
+	return current.model(cfg, stats), nil
+}
+
+// cacheFile returns the path of the cache file of the module at root.
+// Its name is derived from everything, besides the files of the module,
+// that changes how the packages are loaded.
+func cacheFile(root string, cfg *config) (string, error) {
+	dir := cfg.cacheDir
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(userDir, "goarchtest")
+	}
+
+	key := sha256.New()
+	fmt.Fprintf(key, "format=%d\x00root=%s\x00go=%s\x00", cacheFormat, root, runtime.Version())
+	for _, flag := range cfg.buildFlags {
+		fmt.Fprintf(key, "flag=%s\x00", flag)
+	}
+	for _, name := range []string{"GOOS", "GOARCH", "GOFLAGS", "CGO_ENABLED", "GOWORK"} {
+		fmt.Fprintf(key, "%s=%s\x00", name, os.Getenv(name))
+	}
+
+	return filepath.Join(dir, hex.EncodeToString(key.Sum(nil)[:16])+".gob"), nil
+}
+
+// fingerprintModule hashes the module definition and the Go files that
+// "go list ./..." can see from root: test files, nested modules and the
+// directories ignored by the go command are skipped
+func fingerprintModule(root string) (string, error) {
+	var files []string
+
+	// The module definition may live above the analyzed directory
+	for dir := root; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			files = append(files, filepath.Join(dir, "go.mod"), filepath.Join(dir, "go.sum"))
+			break
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := entry.Name()
+		if entry.IsDir() {
+			if path == root {
+				return nil
+			}
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hashFiles(files)
+}
+
+// hashFiles hashes the names and contents of the files; missing files are
+// hashed as such
+func hashFiles(names []string) (string, error) {
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s\x00", name)
+
+		file, err := os.Open(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			hash.Write([]byte("missing\x00"))
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		_, err = io.Copy(hash, file)
+		file.Close()
+		if err != nil {
+			return "", err
+		}
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readSnapshot reads the cached model, returning an empty snapshot if the
+// cache is missing, unreadable or was written in another format
+func readSnapshot(path string) *modelSnapshot {
+	empty := &modelSnapshot{Format: cacheFormat}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return empty
+	}
+	defer file.Close()
+
+	var snapshot modelSnapshot
+	if err := gob.NewDecoder(file).Decode(&snapshot); err != nil || snapshot.Format != cacheFormat {
+		return empty
+	}
+	return &snapshot
+}
+
+// writeSnapshot writes the cached model atomically, so that concurrent runs
+// never read a partial cache
+func writeSnapshot(path string, snapshot *modelSnapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := gob.NewEncoder(file).Encode(snapshot); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,224 @@
+package goarchtest
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// modelIndex holds the lookup structures built once when the packages are loaded,
+// so that predicates do not rescan every type and import.
+// It is safe for concurrent use.
+type modelIndex struct {
+	// typesByPackage lists the types of each package, by import path
+	typesByPackage map[string][]*TypeInfo
+
+	// namespaces is a trie of every known import path: the loaded packages and their imports
+	namespaces *namespaceTrie
+	known      map[string]bool
+
+	// matches caches, per namespace pattern, the known import paths it matches
+	matches sync.Map
+}
+
+// newModelIndex indexes the types and their imports
+func newModelIndex(types []*TypeInfo) *modelIndex {
+	index := &modelIndex{
+		typesByPackage: make(map[string][]*TypeInfo),
+		namespaces:     newNamespaceTrie(),
+		known:          make(map[string]bool),
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range types {
+		index.typesByPackage[t.FullPath] = append(index.typesByPackage[t.FullPath], t)
+		if seen[t.FullPath] {
+			continue
+		}
+
+		// Types of a package share its import set, so it is indexed once
+		seen[t.FullPath] = true
+		index.insert(t.FullPath)
+		for _, imp := range t.Imports {
+			index.insert(imp)
+		}
+	}
+
+	return index
+}
+
+// insert adds a known import path
+func (index *modelIndex) insert(path string) {
+	if !index.known[path] {
+		index.known[path] = true
+		index.namespaces.insert(path)
+	}
+}
+
+// matching returns the set of known import paths matched by the matcher
+func (index *modelIndex) matching(matcher *NamespaceMatcher) map[string]bool {
+	if cached, ok := index.matches.Load(matcher.pattern); ok {
+		return cached.(map[string]bool)
+	}
+
+	matched := index.namespaces.match(matcher)
+	index.matches.Store(matcher.pattern, matched)
+	return matched
+}
+
+// pathMatcher returns a function reporting whether an import path matches any of
+// the patterns. Known paths are looked up in the index; others are matched directly.
+func (ts *TypeSet) pathMatcher(patterns ...string) func(string) bool {
+	matchers := ts.namespaceMatchers(patterns...)
+	if ts.model == nil || ts.model.index == nil {
+		return func(path string) bool {
+			return matchesAny(path, matchers)
+		}
+	}
+
+	index := ts.model.index
+	sets := make([]map[string]bool, len(matchers))
+	for i, matcher := range matchers {
+		sets[i] = index.matching(matcher)
+	}
+
+	return func(path string) bool {
+		if !index.known[path] {
+			return matchesAny(path, matchers)
+		}
+		for _, set := range sets {
+			if set[path] {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// namespaceTrie is a trie of import paths by segment. Glob patterns match every
+// path below a matching prefix, so whole subtrees are matched without scanning them.
+type namespaceTrie struct {
+	root *trieNode
+	size int
+}
+
+type trieNode struct {
+	prefix   string
+	terminal bool
+	children map[string]*trieNode
+}
+
+func newNamespaceTrie() *namespaceTrie {
+	return &namespaceTrie{root: &trieNode{children: make(map[string]*trieNode)}}
+}
+
+// insert adds an import path to the trie
+func (trie *namespaceTrie) insert(path string) {
+	node := trie.root
+	for _, segment := range strings.Split(path, "/") {
+		child, ok := node.children[segment]
+		if !ok {
+			prefix := segment
+			if node.prefix != "" {
+				prefix = node.prefix + "/" + segment
+			}
+			child = &trieNode{prefix: prefix, children: make(map[string]*trieNode)}
+			node.children[segment] = child
+		}
+		node = child
+	}
+
+	if !node.terminal {
+		node.terminal = true
+		trie.size++
+	}
+}
+
+// find returns the node of an import path, or nil
+func (trie *namespaceTrie) find(path string) *trieNode {
+	node := trie.root
+	for _, segment := range strings.Split(path, "/") {
+		node = node.children[segment]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// contains reports whether the import path was inserted
+func (trie *namespaceTrie) contains(path string) bool {
+	node := trie.find(path)
+	return node != nil && node.terminal
+}
+
+// match returns the inserted import paths matched by the matcher
+func (trie *namespaceTrie) match(matcher *NamespaceMatcher) map[string]bool {
+	matched := make(map[string]bool)
+
+	if matcher.mode == MatchExact {
+		if trie.contains(matcher.value) {
+			matched[matcher.value] = true
+		}
+		return matched
+	}
+
+	// A glob matching a prefix matches every path below it, unless it ends at
+	// the last segment of the path
+	prefixClosed := matcher.mode == MatchGlob && matcher.subPackages
+	var walk func(node *trieNode)
+	walk = func(node *trieNode) {
+		if prefixClosed && node.prefix != "" && matcher.Match(node.prefix) {
+			node.collect(matched)
+			return
+		}
+		if node.terminal && matcher.Match(node.prefix) {
+			matched[node.prefix] = true
+		}
+		for _, child := range node.children {
+			walk(child)
+		}
+	}
+	walk(trie.root)
+
+	return matched
+}
+
+// collect adds the paths of the node and its descendants to the set
+func (node *trieNode) collect(paths map[string]bool) {
+	if node.terminal {
+		paths[node.prefix] = true
+	}
+	for _, child := range node.children {
+		child.collect(paths)
+	}
+}
+
+// typeIDs is a set of types identified by their ID, replacing nested loops
+// over type slices with constant-time lookups
+type typeIDs []uint64
+
+// newTypeIDs returns the set of the given types
+func newTypeIDs(types []*TypeInfo) typeIDs {
+	var set typeIDs
+	for _, t := range types {
+		set = set.add(t)
+	}
+	return set
+}
+
+// add returns the set with the type added
+func (set typeIDs) add(t *TypeInfo) typeIDs {
+	word := t.id / 64
+	if word >= len(set) {
+		set = slices.Grow(set, word+1-len(set))[:word+1]
+	}
+	set[word] |= 1 << (t.id % 64)
+	return set
+}
+
+// contains reports whether the type is in the set
+func (set typeIDs) contains(t *TypeInfo) bool {
+	word := t.id / 64
+	return word < len(set) && set[word]&(1<<(t.id%64)) != 0
+}
@@ -0,0 +1,231 @@
+package goarchtest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// MatchMode selects how a namespace pattern is compared with package import paths
+type MatchMode int
+
+const (
+	// MatchGlob matches a contiguous run of path segments anywhere in the import path.
+	// A "*" matches any characters within a single segment and ".." matches any
+	// number of whole segments, including none; the Go-style "..." is read as "..".
+	// A glob using ".." ends at the last segment of the import path unless it
+	// ends with "..". A plain name such as "domain" or "core/domain" is a glob
+	// without wildcards, which also matches sub-packages.
+	MatchGlob MatchMode = iota
+	// MatchRegex matches the import path against a regular expression
+	MatchRegex
+	// MatchExact matches only the identical import path, excluding sub-packages
+	MatchExact
+)
+
+// Pattern prefixes that select a match mode other than MatchGlob
+const (
+	globPrefix  = "glob:"
+	regexPrefix = "regex:"
+	exactPrefix = "exact:"
+)
+
+// String returns the name of the match mode
+func (m MatchMode) String() string {
+	switch m {
+	case MatchGlob:
+		return "glob"
+	case MatchRegex:
+		return "regex"
+	case MatchExact:
+		return "exact"
+	default:
+		return fmt.Sprintf("MatchMode(%d)", int(m))
+	}
+}
+
+// NamespaceMatcher decides whether an import path belongs to a namespace.
+//
+// It is shared by every namespace and dependency predicate (ResideInNamespace,
+// ResideInDirectory, DoNotResideInNamespace, HaveDependencyOn, DoNotHaveDependencyOn,
+// BeAccessedBy and OnlyBeAccessedBy), so a pattern means the same thing everywhere.
+//
+// Pattern syntax:
+//   - "domain", "core/domain": any package whose path contains these whole segments,
+//     including sub-packages ("internal/superuser" does not match "user")
+//   - "internal/*/domain": "*" matches exactly one segment, or part of one ("user*")
+//   - "..domain..", "domain..": the domain package and its sub-packages
+//   - "..domain", "internal..domain": as in ArchUnit, a glob using ".." without a
+//     trailing ".." ends at the last segment, so these match the domain package
+//     but not its sub-packages
+//   - "internal/...": the Go-style "..." is the same as "..", so this matches
+//     internal and every package below it
+//
+// Import paths start with the module path, so a glob may always start at any
+// segment: a leading ".." spells this out but does not change the match.
+//   - "regex:^github.com/myorg/.*/domain$": a regular expression on the full import path
+//   - "exact:github.com/myorg/app/domain": only that package, without sub-packages
+//   - "glob:...": an explicit glob, for patterns that start with another prefix
+//
+// Patterns are not file system paths: a "." segment, as in "./domain", is
+// rejected rather than silently matching nothing.
+type NamespaceMatcher struct {
+	pattern string
+	mode    MatchMode
+	value   string
+	regex   *regexp.Regexp
+
+	// subPackages is set for globs that match the sub-packages of the
+	// packages they match
+	subPackages bool
+}
+
+// matcherCache holds compiled matchers keyed by pattern, since rules reuse
+// the same patterns across many predicates
+var matcherCache sync.Map
+
+// NewNamespaceMatcher parses a namespace pattern into a matcher.
+// It returns an error if the pattern is an invalid regular expression, or a
+// glob with a "." segment.
+//
+// Example:
+//
+//	matcher, err := goarchtest.NewNamespaceMatcher("internal/*/domain")
+//	if err != nil {
+//	    return err
+//	}
+//	matcher.Match("github.com/myorg/app/internal/user/domain") // true
+func NewNamespaceMatcher(pattern string) (*NamespaceMatcher, error) {
+	if cached, ok := matcherCache.Load(pattern); ok {
+		return cached.(*NamespaceMatcher), nil
+	}
+
+	matcher := &NamespaceMatcher{pattern: pattern}
+	switch {
+	case strings.HasPrefix(pattern, regexPrefix):
+		matcher.mode = MatchRegex
+		matcher.value = strings.TrimPrefix(pattern, regexPrefix)
+		regex, err := regexp.Compile(matcher.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+		matcher.regex = regex
+	case strings.HasPrefix(pattern, exactPrefix):
+		matcher.mode = MatchExact
+		matcher.value = strings.TrimPrefix(pattern, exactPrefix)
+	default:
+		matcher.mode = MatchGlob
+		matcher.value = strings.TrimPrefix(pattern, globPrefix)
+		regex, err := compileGlob(matcher.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+		matcher.regex = regex
+		matcher.subPackages = !globEndsAtPath(matcher.value)
+	}
+
+	matcherCache.Store(pattern, matcher)
+	return matcher, nil
+}
+
+// Match reports whether the import path belongs to the namespace
+func (m *NamespaceMatcher) Match(path string) bool {
+	switch m.mode {
+	case MatchExact:
+		return path == m.value
+	default:
+		return m.regex != nil && m.regex.MatchString(path)
+	}
+}
+
+// Mode returns the match mode selected by the pattern
+func (m *NamespaceMatcher) Mode() MatchMode {
+	return m.mode
+}
+
+// String returns the original pattern
+func (m *NamespaceMatcher) String() string {
+	return m.pattern
+}
+
+// ExactNamespace returns a pattern that matches only the given import path
+func ExactNamespace(path string) string {
+	return exactPrefix + path
+}
+
+// RegexNamespace returns a pattern that matches import paths against a regular expression
+func RegexNamespace(expr string) string {
+	return regexPrefix + expr
+}
+
+// compileGlob translates a glob into a regular expression anchored at segment
+// boundaries, and at the end of the path for globs using ".." that do not end
+// with it. An empty glob matches nothing. Segments made of a single ".",
+// left over from relative paths such as "./domain", are rejected: no import
+// path contains them, so the pattern would never match.
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	glob = strings.ReplaceAll(glob, "...", "..")
+
+	var parts []string
+	for _, part := range strings.Split(glob, "..") {
+		part = strings.Trim(part, "/")
+		if part == "" {
+			continue
+		}
+
+		segments := strings.Split(part, "/")
+		for i, segment := range segments {
+			if segment == "." {
+				return nil, fmt.Errorf(`"." is not a package path segment; write namespaces without "./"`)
+			}
+			segments[i] = strings.ReplaceAll(regexp.QuoteMeta(segment), `\*`, `[^/]*`)
+		}
+		parts = append(parts, strings.Join(segments, "/"))
+	}
+
+	if len(parts) == 0 {
+		if strings.Contains(glob, "..") {
+			return regexp.MustCompile(`.*`), nil
+		}
+		return nil, nil
+	}
+
+	end := `(?:/|$)`
+	if globEndsAtPath(glob) {
+		end = `$`
+	}
+	return regexp.MustCompile(`(?:^|/)` + strings.Join(parts, `(?:/[^/]+)*/`) + end), nil
+}
+
+// globEndsAtPath reports whether a glob must end at the last segment of the
+// import path: globs using ".." match sub-packages only when they end with it
+func globEndsAtPath(glob string) bool {
+	glob = strings.ReplaceAll(glob, "...", "..")
+	return strings.Contains(glob, "..") && !strings.HasSuffix(glob, "..")
+}
+
+// namespaceMatchers compiles the patterns for the current predicate.
+// On failure the error is recorded on the TypeSet and nil is returned.
+func (ts *TypeSet) namespaceMatchers(patterns ...string) []*NamespaceMatcher {
+	matchers := make([]*NamespaceMatcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		matcher, err := NewNamespaceMatcher(pattern)
+		if err != nil {
+			ts.recordError(fmt.Errorf("%s: %w", ts.currentPredicate, err))
+			return nil
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers
+}
+
+// matchesAny reports whether the path matches any of the matchers
+func matchesAny(path string, matchers []*NamespaceMatcher) bool {
+	for _, matcher := range matchers {
+		if matcher.Match(path) {
+			return true
+		}
+	}
+	return false
+}
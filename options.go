@@ -0,0 +1,97 @@
+package goarchtest
+
+import (
+	"time"
+)
+
+// Option configures how a Types instance is loaded and how its rules are evaluated
+type Option func(*config)
+
+// config holds the settings applied through Option values
+type config struct {
+	strict            bool
+	emptySelection    EmptySelectionPolicy
+	emptySelectionSet bool
+	registry          *Registry
+	buildFlags        []string
+	loadMode          LoadMode
+	loadTimeout       time.Duration
+	progress          func(Progress)
+	overlay           map[string][]byte
+	cache             bool
+	cacheDir          string
+}
+
+// EmptySelectionPolicy decides what happens when a rule's selection matches no types.
+//
+// A rule such as "types in presentation should not depend on infrastructure"
+// passes trivially when no type resides in presentation, for example after the
+// package was renamed. The policy turns such vacuous rules into warnings or failures.
+type EmptySelectionPolicy int
+
+const (
+	// EmptySelectionAllow evaluates vacuous rules as usual
+	EmptySelectionAllow EmptySelectionPolicy = iota
+	// EmptySelectionWarn adds a warning to the Result of a vacuous rule
+	EmptySelectionWarn
+	// EmptySelectionFail makes vacuous rules fail, with a warning explaining why
+	EmptySelectionFail
+)
+
+// newConfig applies the options on top of the defaults
+func newConfig(opts []Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithStrictMode enables strict evaluation.
+// In strict mode rules whose selection matches no types fail, unless
+// WithEmptySelectionPolicy sets another policy.
+//
+// Example:
+//
+//	types := goarchtest.InPath("./", goarchtest.WithStrictMode())
+func WithStrictMode() Option {
+	return func(c *config) {
+		c.strict = true
+	}
+}
+
+// WithEmptySelectionPolicy sets how rules whose selection matches no types are reported.
+//
+// Example:
+//
+//	types := goarchtest.InPath("./", goarchtest.WithEmptySelectionPolicy(goarchtest.EmptySelectionWarn))
+func WithEmptySelectionPolicy(policy EmptySelectionPolicy) Option {
+	return func(c *config) {
+		c.emptySelection = policy
+		c.emptySelectionSet = true
+	}
+}
+
+// emptySelectionPolicy returns the effective policy, which defaults to
+// EmptySelectionFail in strict mode
+func (c *config) emptySelectionPolicy() EmptySelectionPolicy {
+	if c.emptySelectionSet {
+		return c.emptySelection
+	}
+	if c.strict {
+		return EmptySelectionFail
+	}
+	return EmptySelectionAllow
+}
+
+// WithBuildFlags passes build flags, such as "-tags=integration", to the build
+// system when the packages are loaded.
+//
+// Example:
+//
+//	types := goarchtest.InPath("./", goarchtest.WithBuildFlags("-tags=integration"))
+func WithBuildFlags(flags ...string) Option {
+	return func(c *config) {
+		c.buildFlags = append(c.buildFlags, flags...)
+	}
+}
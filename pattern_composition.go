@@ -0,0 +1,143 @@
+package goarchtest
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// With returns a copy of the pattern with the given rules added
+//
+// Example:
+//
+//	pattern := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation").
+//	    With(goarchtest.Rule{
+//	        ID:          "conventions/handlers-in-presentation",
+//	        Description: "Handlers should reside in the presentation layer",
+//	        Validate:    validateHandlers,
+//	    })
+func (ap *ArchitecturePattern) With(rules ...Rule) *ArchitecturePattern {
+	composed := ap.clone()
+	composed.Rules = append(composed.Rules, rules...)
+	return composed
+}
+
+// Without returns a copy of the pattern without the rules with the given IDs,
+// including the rules of nested patterns. IDs that match no rule are ignored.
+// Rules without an ID or a description are matched by the ID reported in their
+// ValidationResult, which is generated from their chain when they are validated.
+//
+// Example:
+//
+//	pattern := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation").
+//	    Without("clean-architecture/presentation-not-depend-on-infrastructure")
+func (ap *ArchitecturePattern) Without(ruleIDs ...string) *ArchitecturePattern {
+	composed := ap.clone()
+	composed.Rules = slices.DeleteFunc(composed.Rules, func(rule Rule) bool {
+		return !rule.hasGeneratedID() && slices.Contains(ruleIDs, ap.ruleID(rule))
+	})
+	for i, rule := range composed.Rules {
+		if rule.hasGeneratedID() {
+			composed.Rules[i].excluded = append(slices.Clip(rule.excluded), ruleIDs...)
+		}
+	}
+	for i, pattern := range composed.Patterns {
+		composed.Patterns[i] = pattern.Without(ruleIDs...)
+	}
+	return composed
+}
+
+// Merge returns a copy of the pattern with the rules and nested patterns of
+// another pattern added. The merged rules keep the IDs they had in the other
+// pattern; the IDs passed to Without and Override on either pattern only
+// apply to the rules the pattern had then.
+//
+// Example:
+//
+//	pattern := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation").
+//	    Merge(goarchtest.CQRSArchitecture("commands", "queries", "domain", "write", "read"))
+func (ap *ArchitecturePattern) Merge(other *ArchitecturePattern) *ArchitecturePattern {
+	composed := ap.clone()
+	for _, rule := range other.Rules {
+		if rule.hasGeneratedID() {
+			// The ID is generated when the rule is validated, from the other pattern's name
+			rule.pattern = cmp.Or(rule.pattern, other.Name)
+		} else {
+			rule.ID = other.ruleID(rule)
+		}
+		composed.Rules = append(composed.Rules, rule)
+	}
+	composed.Patterns = append(composed.Patterns, other.Patterns...)
+	return composed
+}
+
+// Override returns a copy of the pattern where the rule with the given ID,
+// including rules of nested patterns, has the given severity.
+// IDs that match no rule are ignored. As with Without, rules without an ID or
+// a description are matched by the ID generated when they are validated.
+//
+// Example:
+//
+//	pattern := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation").
+//	    Override("clean-architecture/presentation-not-depend-on-infrastructure", goarchtest.SeverityWarning)
+func (ap *ArchitecturePattern) Override(ruleID string, severity Severity) *ArchitecturePattern {
+	composed := ap.clone()
+	for i, rule := range composed.Rules {
+		switch {
+		case rule.hasGeneratedID():
+			composed.Rules[i].overrides = append(slices.Clip(rule.overrides), severityOverride{ruleID, severity})
+		case ap.ruleID(rule) == ruleID:
+			composed.Rules[i].Severity = severity
+		}
+	}
+	for i, pattern := range composed.Patterns {
+		composed.Patterns[i] = pattern.Override(ruleID, severity)
+	}
+	return composed
+}
+
+// Nest returns a copy of the pattern with the given patterns nested in it.
+// The results of nested patterns carry their own PatternName and a PatternPath
+// starting with the enclosing pattern, so they can be grouped with GroupByPattern.
+//
+// Example:
+//
+//	service := (&goarchtest.ArchitecturePattern{Name: "Order Service"}).Nest(
+//	    goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation"),
+//	    goarchtest.CQRSArchitecture("commands", "queries", "domain", "write", "read"),
+//	)
+func (ap *ArchitecturePattern) Nest(patterns ...*ArchitecturePattern) *ArchitecturePattern {
+	composed := ap.clone()
+	composed.Patterns = append(composed.Patterns, patterns...)
+	return composed
+}
+
+// clone returns a copy of the pattern that can be modified without affecting it
+func (ap *ArchitecturePattern) clone() *ArchitecturePattern {
+	return &ArchitecturePattern{
+		Name:        ap.Name,
+		Rules:       slices.Clone(ap.Rules),
+		Patterns:    slices.Clone(ap.Patterns),
+		Parallelism: ap.Parallelism,
+	}
+}
+
+// GroupByPattern groups validation results by the pattern that produced them,
+// keyed by the pattern path joined with " > ", e.g. "Order Service > Clean Architecture".
+// The original order is kept within each group.
+func GroupByPattern(results []*ValidationResult) map[string][]*ValidationResult {
+	groups := make(map[string][]*ValidationResult)
+	for _, result := range results {
+		key := result.patternKey()
+		groups[key] = append(groups[key], result)
+	}
+	return groups
+}
+
+// patternKey identifies the pattern that produced the result
+func (vr *ValidationResult) patternKey() string {
+	if len(vr.PatternPath) == 0 {
+		return vr.PatternName
+	}
+	return strings.Join(vr.PatternPath, " > ")
+}
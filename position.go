@@ -0,0 +1,86 @@
+package goarchtest
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Position locates a type declaration or an import in the analyzed sources.
+// File is the path of the source file as loaded; Line and Column are 1-based.
+type Position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column,omitempty"`
+}
+
+// IsValid reports whether the position is known
+func (p Position) IsValid() bool {
+	return p.File != "" && p.Line > 0
+}
+
+// String returns the position as "file:line:column", or "-" if it is unknown
+func (p Position) String() string {
+	switch {
+	case !p.IsValid():
+		return "-"
+	case p.Column > 0:
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	default:
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+}
+
+// ImportPosition returns the position of the import of the given path in the
+// package of the type, preferring the file declaring the type. It returns the
+// zero Position if the package does not import the path.
+func (t *TypeInfo) ImportPosition(importPath string) Position {
+	positions := t.importPositions[importPath]
+	for _, position := range positions {
+		if position.File == t.Position.File {
+			return position
+		}
+	}
+	if len(positions) > 0 {
+		return positions[0]
+	}
+	return Position{}
+}
+
+// newPosition converts a go/token position
+func newPosition(fset *token.FileSet, pos token.Pos) Position {
+	if fset == nil || !pos.IsValid() {
+		return Position{}
+	}
+	position := fset.Position(pos)
+	return Position{File: position.Filename, Line: position.Line, Column: position.Column}
+}
+
+// importPositions returns the positions of the imports of the files, by import path
+func importPositions(fset *token.FileSet, files []*ast.File) map[string][]Position {
+	positions := make(map[string][]Position)
+	for _, file := range files {
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			positions[importPath] = append(positions[importPath], newPosition(fset, spec.Pos()))
+		}
+	}
+	return positions
+}
+
+// relativeTo makes the position relative to the directory, if it lies within it
+func (p Position) relativeTo(dir string) Position {
+	if p.File == "" || dir == "" {
+		return p
+	}
+	if rel, err := filepath.Rel(dir, p.File); err == nil && !strings.HasPrefix(rel, "..") {
+		p.File = filepath.ToSlash(rel)
+	}
+	return p
+}
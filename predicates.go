@@ -1,13 +1,14 @@
 package goarchtest
 
-import (
-	"strings"
-)
+import "fmt"
 
 // ResideInNamespace filters types that reside in the specified namespace/package
 // It allows for filtering based on the package namespace of the type.
+// The namespace is a pattern understood by NamespaceMatcher: a plain path such as
+// "domain" matches any package containing that segment, including sub-packages,
+// and globs, regular expressions and exact paths are supported as well.
 // Parameters:
-//   - namespace: A string representing the namespace to match against type packages
+//   - namespace: A string representing the namespace pattern to match against type packages
 //
 // Returns:
 //   - *TypeSet: Returns the filtered TypeSet containing only types whose packages match the namespace,
@@ -16,43 +17,29 @@ import (
 // Example:
 //
 //	typeSet.ResideInNamespace("github.com/myorg/mypackage")
+//	typeSet.ResideInNamespace("internal/*/domain")
 func (ts *TypeSet) ResideInNamespace(namespace string) *TypeSet {
 	ts.currentPredicate = "ResideInNamespace"
 
+	matches := ts.pathMatcher(namespace)
+
 	var filteredTypes []*TypeInfo
 	for _, t := range ts.types {
-		// Check exact match first
-		if t.FullPath == namespace {
-			filteredTypes = append(filteredTypes, t)
-			continue
-		}
-		
-		// Check if namespace matches the end of the FullPath (relative path matching)
-		if strings.HasSuffix(t.FullPath, "/"+namespace) || strings.Contains(t.FullPath, "/"+namespace+"/") {
+		if matches(t.FullPath) {
 			filteredTypes = append(filteredTypes, t)
-			continue
-		}
-		
-		// Also check prefix match for full paths
-		if strings.HasPrefix(t.FullPath, namespace+"/") {
-			filteredTypes = append(filteredTypes, t)
-			continue
 		}
 	}
 
 	// Create a new TypeSet to avoid modifying the original
-	newTypeSet := &TypeSet{
-		types:             filteredTypes,
-		originalTypes:     ts.originalTypes, // Keep reference to original types
-		currentPredicate:  ts.currentPredicate,
-		matchedPredicates: append([]string{}, ts.matchedPredicates...), // Copy slice
-	}
+	newTypeSet := ts.derive(filteredTypes)
+	newTypeSet.describe(fmt.Sprintf("reside in namespace '%s'", namespace))
 	newTypeSet.matchedPredicates = append(newTypeSet.matchedPredicates, ts.currentPredicate)
 	return newTypeSet
 }
 
 // HaveDependencyOn filters types that have a dependency on the specified package
 // It allows for filtering based on the import statements of the type.
+// The dependency is a namespace pattern, matched the same way as in ResideInNamespace.
 // Parameters:
 //   - dependency: A string representing the package dependency to match against type imports
 //
@@ -66,42 +53,34 @@ func (ts *TypeSet) ResideInNamespace(namespace string) *TypeSet {
 func (ts *TypeSet) HaveDependencyOn(dependency string) *TypeSet {
 	ts.currentPredicate = "HaveDependencyOn"
 
+	matches := ts.pathMatcher(dependency)
+
 	var filteredTypes []*TypeInfo
+	var evidence []Violation
 	for _, t := range ts.types {
+		matched := false
 		for _, imp := range t.Imports {
-			// Exact match
-			if imp == dependency {
-				filteredTypes = append(filteredTypes, t)
-				break
-			}
-			
-			// Prefix match with slash (for exact package boundaries)
-			if strings.HasPrefix(imp, dependency+"/") {
-				filteredTypes = append(filteredTypes, t)
-				break
-			}
-			
-			// Suffix match for relative path matching (e.g., "infrastructure" matches "*/infrastructure")
-			if strings.HasSuffix(imp, "/"+dependency) {
-				filteredTypes = append(filteredTypes, t)
-				break
-			}
-			
-			// Contains match for partial path matching (e.g., "infrastructure" matches "*/infrastructure/*")
-			if strings.Contains(imp, "/"+dependency+"/") {
-				filteredTypes = append(filteredTypes, t)
-				break
+			if matches(imp) {
+				matched = true
+				evidence = append(evidence, Violation{
+					Type:     t,
+					Reason:   fmt.Sprintf("%s imports %s", t.FullPath, imp),
+					Evidence: imp,
+					Position: t.ImportPosition(imp),
+				})
 			}
 		}
+		if matched {
+			filteredTypes = append(filteredTypes, t)
+		}
 	}
 
 	// Create a new TypeSet to avoid modifying the original
-	newTypeSet := &TypeSet{
-		types:             filteredTypes,
-		originalTypes:     ts.originalTypes, // Keep reference to original types
-		currentPredicate:  ts.currentPredicate,
-		matchedPredicates: append([]string{}, ts.matchedPredicates...), // Copy slice
+	newTypeSet := ts.derive(filteredTypes)
+	for _, v := range evidence {
+		newTypeSet.recordMatch(v)
 	}
+	newTypeSet.describe(fmt.Sprintf("have dependency on '%s'", dependency))
 	newTypeSet.matchedPredicates = append(newTypeSet.matchedPredicates, ts.currentPredicate)
 	return newTypeSet
 }
@@ -132,6 +111,7 @@ func (ts *TypeSet) ImplementInterface(interfaceName string) *TypeSet {
 	}
 
 	ts.types = filteredTypes
+	ts.describe(fmt.Sprintf("implement interface '%s'", interfaceName))
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
@@ -156,6 +136,7 @@ func (ts *TypeSet) BeStruct() *TypeSet {
 	}
 
 	ts.types = filteredTypes
+	ts.describe("be structs")
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
@@ -171,6 +152,7 @@ func (ts *TypeSet) BeStruct() *TypeSet {
 func (ts *TypeSet) And() *TypeSet {
 	ts.currentPredicate = "And"
 	// No filtering needed, this is just a logical connector
+	ts.connect("and")
 	return ts
 }
 
@@ -186,20 +168,20 @@ func (ts *TypeSet) Or(other *TypeSet) *TypeSet {
 	ts.currentPredicate = "Or"
 
 	// Create a union of the two type sets
-	unionMap := make(map[string]bool)
-	for _, t := range ts.types {
-		key := t.Package + "." + t.Name
-		unionMap[key] = true
-	}
-
+	union := newTypeIDs(ts.types)
 	for _, t := range other.types {
-		key := t.Package + "." + t.Name
-		if !unionMap[key] {
+		if !union.contains(t) {
 			ts.types = append(ts.types, t)
-			unionMap[key] = true
+			union = union.add(t)
+		}
+		for _, v := range other.matchEvidence[t] {
+			ts.recordMatch(v)
 		}
 	}
 
+	ts.connect("or (" + other.Description() + ")")
+	ts.joinable = true
+
 	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
 	return ts
 }
@@ -217,6 +199,9 @@ func (ts *TypeSet) Should() *TypeSet {
 	// Store the current types for later reference
 	originalTypes := ts.types
 	ts.originalTypes = originalTypes
+	ts.conditioned = true
+	ts.selected = originalTypes
+	ts.connect("should")
 	return ts
 }
 
@@ -231,12 +216,10 @@ func (ts *TypeSet) Should() *TypeSet {
 func (ts *TypeSet) ShouldNot() *TypeSet {
 	ts.currentPredicate = "ShouldNot"
 	// Create a new TypeSet to avoid modifying the original
-	newTypeSet := &TypeSet{
-		types:             append([]*TypeInfo{}, ts.types...), // Copy types slice
-		originalTypes:     ts.originalTypes,
-		currentPredicate:  ts.currentPredicate,
-		matchedPredicates: append([]string{}, ts.matchedPredicates...), // Copy slice
-	}
+	newTypeSet := ts.derive(append([]*TypeInfo{}, ts.types...)) // Copy types slice
+	newTypeSet.connect("should not")
+	newTypeSet.conditioned = true
+	newTypeSet.selected = ts.types
 	newTypeSet.matchedPredicates = append(newTypeSet.matchedPredicates, "Negate")
 	return newTypeSet
 }
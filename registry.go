@@ -0,0 +1,263 @@
+package goarchtest
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Registry holds named predicates and conditions, so conventions can be written
+// once and referred to by name from the fluent API (Are and Be) and from
+// declarative configuration.
+//
+// The global registry ships with a catalog of built-in conventions, such as
+// "repository", "handler" and "valueObject". Scoped registries created with
+// NewRegistry fall back to the global registry for names they do not define.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	parent     *Registry
+	predicates map[string]ContextPredicate
+	conditions map[string]Condition
+}
+
+// globalRegistry is used by the package-level Register functions and holds
+// the built-in catalog
+var globalRegistry = builtinRegistry()
+
+// NewRegistry creates a scoped registry. Names it does not define are looked
+// up in the global registry. Use it with WithRegistry to keep conventions local
+// to one test suite.
+//
+// Example:
+//
+//	registry := goarchtest.NewRegistry()
+//	registry.RegisterPredicate("useCase", func(t *goarchtest.TypeInfo) bool {
+//	    return t.IsStruct && strings.HasSuffix(t.Name, "UseCase")
+//	})
+//	types := goarchtest.InPath("./", goarchtest.WithRegistry(registry))
+func NewRegistry() *Registry {
+	return newRegistry(globalRegistry)
+}
+
+func newRegistry(parent *Registry) *Registry {
+	return &Registry{
+		parent:     parent,
+		predicates: make(map[string]ContextPredicate),
+		conditions: make(map[string]Condition),
+	}
+}
+
+// RegisterPredicate registers a named predicate in the global registry.
+// Registering a name again replaces the previous predicate, including built-in ones.
+//
+// Example:
+//
+//	goarchtest.RegisterPredicate("isService", func(t *goarchtest.TypeInfo) bool {
+//	    return t.IsStruct && strings.HasSuffix(t.Name, "Service")
+//	})
+//
+//	result := types.That().Are("isService").Should().ResideInNamespace("application").GetResult()
+func RegisterPredicate(name string, predicate CustomPredicate) {
+	globalRegistry.RegisterPredicate(name, predicate)
+}
+
+// RegisterContextPredicate registers a named model-aware predicate in the global registry
+func RegisterContextPredicate(name string, predicate ContextPredicate) {
+	globalRegistry.RegisterContextPredicate(name, predicate)
+}
+
+// RegisterCondition registers a named condition in the global registry.
+// Registering a name again replaces the previous condition.
+//
+// Example:
+//
+//	goarchtest.RegisterCondition("documented", func(t *goarchtest.TypeInfo) (bool, string) {
+//	    if hasDocComment(t) {
+//	        return true, ""
+//	    }
+//	    return false, t.Name + " has no doc comment"
+//	})
+//
+//	result := types.That().ResideInNamespace("api").Should().Be("documented").GetResult()
+func RegisterCondition(name string, condition Condition) {
+	globalRegistry.RegisterCondition(name, condition)
+}
+
+// RegisterPredicate registers a named predicate in the registry
+func (r *Registry) RegisterPredicate(name string, predicate CustomPredicate) {
+	r.RegisterContextPredicate(name, func(_ *PredicateContext, t *TypeInfo) bool {
+		return predicate(t)
+	})
+}
+
+// RegisterContextPredicate registers a named model-aware predicate in the registry
+func (r *Registry) RegisterContextPredicate(name string, predicate ContextPredicate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.predicates[name] = predicate
+}
+
+// RegisterCondition registers a named condition in the registry
+func (r *Registry) RegisterCondition(name string, condition Condition) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.conditions[name] = condition
+}
+
+// Predicate looks up a named predicate, falling back to the global registry
+func (r *Registry) Predicate(name string) (ContextPredicate, bool) {
+	r.mu.RLock()
+	predicate, ok := r.predicates[name]
+	r.mu.RUnlock()
+
+	if !ok && r.parent != nil {
+		return r.parent.Predicate(name)
+	}
+	return predicate, ok
+}
+
+// Condition looks up a named condition, falling back to the global registry
+func (r *Registry) Condition(name string) (Condition, bool) {
+	r.mu.RLock()
+	condition, ok := r.conditions[name]
+	r.mu.RUnlock()
+
+	if !ok && r.parent != nil {
+		return r.parent.Condition(name)
+	}
+	return condition, ok
+}
+
+// Predicates returns the names of all predicates available in the registry, sorted
+func (r *Registry) Predicates() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var names []string
+	if r.parent != nil {
+		names = r.parent.Predicates()
+	}
+	for name := range r.predicates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// Conditions returns the names of all conditions registered in the registry, sorted.
+// Be accepts the names of predicates as well.
+func (r *Registry) Conditions() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var names []string
+	if r.parent != nil {
+		names = r.parent.Conditions()
+	}
+	for name := range r.conditions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// WithRegistry sets the registry used to resolve named predicates and conditions.
+// By default the global registry is used.
+func WithRegistry(registry *Registry) Option {
+	return func(c *config) {
+		c.registry = registry
+	}
+}
+
+// registry returns the registry that resolves the names used in the chain
+func (ts *TypeSet) registry() *Registry {
+	if ts.model != nil && ts.model.config != nil && ts.model.config.registry != nil {
+		return ts.model.config.registry
+	}
+	return globalRegistry
+}
+
+// Are filters the TypeSet with a registered predicate, by name.
+// An unknown name makes the rule fail with Result.Err set.
+//
+// Parameters:
+//   - name: The name of a registered or built-in predicate
+//
+// Returns:
+//   - *TypeSet: Returns the filtered TypeSet, allowing for method chaining
+//
+// Example:
+//
+//	result := types.That().
+//	    Are("repository").
+//	    Should().
+//	    ResideInNamespace("infrastructure").
+//	    GetResult()
+func (ts *TypeSet) Are(name string) *TypeSet {
+	ts.currentPredicate = name
+
+	predicate, ok := ts.registry().Predicate(name)
+	if !ok {
+		ts.recordError(fmt.Errorf("unknown predicate %q", name))
+		predicate = func(*PredicateContext, *TypeInfo) bool { return false }
+	}
+
+	ts.types = ts.filterWithContext(predicate)
+	ts.describe(fmt.Sprintf("be '%s'", name))
+	ts.matchedPredicates = append(ts.matchedPredicates, ts.currentPredicate)
+	return ts
+}
+
+// Be checks a registered condition, by name. Like Satisfy, every selected type
+// must satisfy it, and the reason given for each type is reported as its Violation.
+// Registered predicates can be used as conditions as well.
+// An unknown name makes the rule fail with Result.Err set.
+//
+// Parameters:
+//   - name: The name of a registered or built-in condition or predicate
+//
+// Returns:
+//   - *TypeSet: Returns the TypeSet containing only types that satisfy the condition,
+//     allowing for method chaining
+//
+// Example:
+//
+//	result := types.That().
+//	    ResideInNamespace("domain/valueobjects").
+//	    Should().
+//	    Be("immutable").
+//	    GetResult()
+func (ts *TypeSet) Be(name string) *TypeSet {
+	ts.currentPredicate = name
+
+	return ts.satisfied(fmt.Sprintf("be '%s'", name), ts.filterWithCondition(ts.namedCondition(name)))
+}
+
+// namedCondition resolves a condition by name. Predicates are turned into
+// conditions: a type satisfies the condition when it matches the predicate.
+func (ts *TypeSet) namedCondition(name string) Condition {
+	registry := ts.registry()
+	if condition, ok := registry.Condition(name); ok {
+		return condition
+	}
+
+	predicate, ok := registry.Predicate(name)
+	if !ok {
+		ts.recordError(fmt.Errorf("unknown condition %q", name))
+		return func(*TypeInfo) (bool, string) { return false, "" }
+	}
+
+	return func(t *TypeInfo) (bool, string) {
+		ctx := &PredicateContext{model: ts.model, set: ts, typ: t}
+		ok := predicate(ctx, t)
+		switch {
+		case ctx.reason != "":
+			return ok, ctx.reason
+		case ok:
+			return true, fmt.Sprintf("%s is '%s'", t.Name, name)
+		default:
+			return false, fmt.Sprintf("%s is not '%s'", t.Name, name)
+		}
+	}
+}
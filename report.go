@@ -0,0 +1,315 @@
+package goarchtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ReportVersion is the version of the Report schema.
+// It changes only when fields are renamed, removed or change meaning;
+// new optional fields may be added within a version.
+const ReportVersion = 1
+
+// ungroupedRules names the group of results added without a pattern
+const ungroupedRules = "Architecture Rules"
+
+// Report is the machine-readable report of architecture test results, written
+// by Reporter.SaveReport("json", ...) and read back by LoadReport.
+//
+// Example of the JSON schema:
+//
+//	{
+//	  "version": 1,
+//	  "metadata": {"tool": "goarchtest", "generatedAt": "2025-01-02T15:04:05Z", "goVersion": "go1.24.1"},
+//	  "summary": {"total": 1, "passed": 0, "failed": 1},
+//	  "rules": [{
+//	    "id": "clean/domain-not-depend-on-infrastructure",
+//	    "description": "Domain layer should not depend on infrastructure",
+//	    "severity": "error",
+//	    "pattern": "Clean Architecture",
+//	    "passed": false,
+//	    "selectedCount": 3,
+//	    "evaluatedCount": 3,
+//	    "violations": [{
+//	      "type": "User",
+//	      "package": "domain",
+//	      "path": "example.com/app/domain",
+//	      "reason": "example.com/app/domain imports example.com/app/infrastructure",
+//	      "evidence": "example.com/app/infrastructure",
+//	      "position": {"file": "domain/user.go", "line": 5, "column": 2}
+//	    }]
+//	  }]
+//	}
+type Report struct {
+	Version  int            `json:"version"`
+	Metadata ReportMetadata `json:"metadata"`
+	Summary  ReportSummary  `json:"summary"`
+	Rules    []RuleReport   `json:"rules"`
+}
+
+// ReportMetadata describes the run that produced a Report
+type ReportMetadata struct {
+	Tool        string    `json:"tool"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	GoVersion   string    `json:"goVersion"`
+}
+
+// ReportSummary counts the rules of a Report by outcome
+type ReportSummary struct {
+	Total  int `json:"total"`
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+// RuleReport is the outcome of a single rule.
+// Pattern and PatternPath are empty for results added with Reporter.AddResult,
+// which carry no rule metadata besides the description.
+type RuleReport struct {
+	ID             string            `json:"id,omitempty"`
+	Description    string            `json:"description"`
+	Severity       Severity          `json:"severity"`
+	Pattern        string            `json:"pattern,omitempty"`
+	PatternPath    []string          `json:"patternPath,omitempty"`
+	Tags           []string          `json:"tags,omitempty"`
+	Rationale      string            `json:"rationale,omitempty"`
+	DocLinks       []string          `json:"docLinks,omitempty"`
+	Passed         bool              `json:"passed"`
+	SelectedCount  int               `json:"selectedCount"`
+	EvaluatedCount int               `json:"evaluatedCount"`
+	FrozenCount    int               `json:"frozenCount,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	Warnings       []string          `json:"warnings,omitempty"`
+	Violations     []ViolationReport `json:"violations,omitempty"`
+	Exemptions     []ExemptionReport `json:"exemptions,omitempty"`
+}
+
+// ViolationReport is a single violation of a rule.
+// Position is omitted when the location of the violation is unknown.
+type ViolationReport struct {
+	Type     string    `json:"type"`
+	Package  string    `json:"package"`
+	Path     string    `json:"path"`
+	Reason   string    `json:"reason,omitempty"`
+	Evidence string    `json:"evidence,omitempty"`
+	Position *Position `json:"position,omitempty"`
+}
+
+// ExemptionReport is a type excluded from a rule, with the documented reason
+type ExemptionReport struct {
+	Type    string `json:"type"`
+	Package string `json:"package"`
+	Path    string `json:"path"`
+	Reason  string `json:"reason"`
+}
+
+// Report builds the machine-readable report of the results added to the
+// Reporter: first the results added with AddResult, then the validation results.
+// File positions are relative to BaseDir, or to the working directory when
+// BaseDir is empty, if they lie within it.
+func (r *Reporter) Report() *Report {
+	baseDir := r.BaseDir
+	if baseDir == "" {
+		baseDir, _ = os.Getwd()
+	}
+	if abs, err := filepath.Abs(baseDir); err == nil {
+		baseDir = abs
+	}
+
+	report := &Report{
+		Version: ReportVersion,
+		Metadata: ReportMetadata{
+			Tool:        "goarchtest",
+			GeneratedAt: time.Now().UTC(),
+			GoVersion:   runtime.Version(),
+		},
+		Rules: []RuleReport{},
+	}
+
+	for _, result := range r.Results {
+		report.add(RuleReport{
+			Description:    result.Description,
+			Severity:       SeverityError,
+			Passed:         result.IsSuccessful,
+			SelectedCount:  result.SelectedCount,
+			EvaluatedCount: result.EvaluatedCount,
+			Error:          errorString(result.Err),
+			Warnings:       result.Warnings,
+			Violations:     violationReports(result.FailingTypes, result.Violations, baseDir),
+			Exemptions:     exemptionReports(result.Exemptions),
+		})
+	}
+
+	for _, result := range r.ValidationResults {
+		report.add(RuleReport{
+			ID:             result.RuleID,
+			Description:    result.RuleDescription,
+			Severity:       result.Severity.orDefault(),
+			Pattern:        result.PatternName,
+			PatternPath:    result.PatternPath,
+			Tags:           result.Tags,
+			Rationale:      result.Rationale,
+			DocLinks:       result.DocLinks,
+			Passed:         result.IsSuccessful,
+			SelectedCount:  result.SelectedCount,
+			EvaluatedCount: result.EvaluatedCount,
+			FrozenCount:    result.FrozenCount,
+			Error:          errorString(result.Err),
+			Warnings:       result.Warnings,
+			Violations:     violationReports(result.FailingTypes, result.Violations, baseDir),
+			Exemptions:     exemptionReports(result.Exemptions),
+		})
+	}
+
+	return report
+}
+
+// add appends a rule to the report and counts it in the summary
+func (report *Report) add(rule RuleReport) {
+	report.Rules = append(report.Rules, rule)
+	report.Summary.Total++
+	if rule.Passed {
+		report.Summary.Passed++
+	} else {
+		report.Summary.Failed++
+	}
+}
+
+// group names the group of a rule in reports: its pattern path, or
+// "Architecture Rules" for results added without a pattern
+func (rule *RuleReport) group() string {
+	switch {
+	case len(rule.PatternPath) > 0:
+		return strings.Join(rule.PatternPath, " > ")
+	case rule.Pattern != "":
+		return rule.Pattern
+	default:
+		return ungroupedRules
+	}
+}
+
+// violationReports converts violations, making their positions relative to baseDir.
+// Failing types without a recorded violation are reported as violations without a reason.
+func violationReports(failingTypes []*TypeInfo, violations []Violation, baseDir string) []ViolationReport {
+	var reports []ViolationReport
+	for _, v := range withFailingTypes(failingTypes, violations) {
+		report := ViolationReport{
+			Reason:   v.Reason,
+			Evidence: v.Evidence,
+		}
+		if v.Type != nil {
+			report.Type, report.Package, report.Path = v.Type.Name, v.Type.Package, v.Type.FullPath
+		}
+		if v.Position.IsValid() {
+			position := v.Position.relativeTo(baseDir)
+			report.Position = &position
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// withFailingTypes returns the violations along with a violation without a
+// reason for every failing type that has none, as in results built by hand
+// or failed existence requirements
+func withFailingTypes(failingTypes []*TypeInfo, violations []Violation) []Violation {
+	for _, t := range failingTypes {
+		if t != nil && !slices.ContainsFunc(violations, func(v Violation) bool { return v.Type == t }) {
+			violations = append(slices.Clip(violations), Violation{Type: t, Position: t.Position})
+		}
+	}
+	return violations
+}
+
+// exemptionReports converts exemptions
+func exemptionReports(exemptions []Exemption) []ExemptionReport {
+	var reports []ExemptionReport
+	for _, exemption := range exemptions {
+		reports = append(reports, ExemptionReport{
+			Type:    exemption.Type.Name,
+			Package: exemption.Type.Package,
+			Path:    exemption.Type.FullPath,
+			Reason:  exemption.Reason,
+		})
+	}
+	return reports
+}
+
+// errorString returns the message of an error, or an empty string
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// GenerateJSONReport generates the JSON report of the architecture test results.
+// See Report for the schema.
+func (r *Reporter) GenerateJSONReport() (string, error) {
+	return r.render(JSONFormatter{})
+}
+
+// JSONFormatter writes reports as indented JSON, registered as "json".
+// See Report for the schema.
+type JSONFormatter struct{}
+
+// Format writes the report as JSON
+func (JSONFormatter) Format(w io.Writer, report *Report) error {
+	data, err := report.MarshalIndent()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// MarshalIndent encodes the report as indented JSON, ending with a newline
+func (report *Report) MarshalIndent() ([]byte, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Save writes the report to a file as JSON
+func (report *Report) Save(path string) error {
+	data, err := report.MarshalIndent()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadReport reads a JSON report written by SaveReport("json", ...) or Report.Save.
+// Reports written with a newer schema version are rejected.
+//
+// Example:
+//
+//	report, err := goarchtest.LoadReport("archtest-report.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d of %d rules failed\n", report.Summary.Failed, report.Summary.Total)
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid report file %s: %w", path, err)
+	}
+	if report.Version < 1 || report.Version > ReportVersion {
+		return nil, fmt.Errorf("unsupported report version %d in %s", report.Version, path)
+	}
+
+	return &report, nil
+}
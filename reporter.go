@@ -2,15 +2,23 @@ package goarchtest
 
 import (
 	"fmt"
+	"html"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 )
 
-// Reporter generates reports about architecture test results
+// Reporter generates reports about architecture test results.
+// Results are added one by one with AddResult, or with their rule metadata
+// from a pattern validation with AddValidationResults.
 type Reporter struct {
-	Results []*Result
+	Results           []*Result
+	ValidationResults []*ValidationResult
+
+	// BaseDir is the directory file positions are reported relative to;
+	// the working directory when empty
+	BaseDir string
 }
 
 // NewReporter creates a new reporter instance
@@ -30,39 +38,96 @@ func (r *Reporter) AddResult(result *Result) {
 	r.Results = append(r.Results, result)
 }
 
+// AddValidationResults appends the results of a pattern validation to the Reporter.
+// Unlike AddResult, the rule ID, description, severity and pattern of each
+// result are kept, and shown in every report format.
+//
+// Example:
+//
+//	reporter.AddValidationResults(goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation").Validate(types))
+func (r *Reporter) AddValidationResults(results []*ValidationResult) {
+	r.ValidationResults = append(r.ValidationResults, results...)
+}
+
 // GenerateTextReport generates a text report of the architecture test results.
 // It summarizes the test outcomes, including the number of passed and failed tests,
 // and details about any failing types.
 func (r *Reporter) GenerateTextReport() string {
-	var report strings.Builder
+	report, _ := r.render(TextFormatter{})
+	return report
+}
+
+// TextFormatter writes reports as plain text, registered as "text"
+type TextFormatter struct{}
 
-	report.WriteString("GoArchTest Report\n")
-	report.WriteString("================\n\n")
-	report.WriteString(fmt.Sprintf("Date: %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
+// Format writes the report as plain text
+func (TextFormatter) Format(w io.Writer, report *Report) error {
+	var out strings.Builder
 
-	passCount := 0
-	failCount := 0
+	out.WriteString("GoArchTest Report\n")
+	out.WriteString("================\n\n")
+	out.WriteString(fmt.Sprintf("Date: %s\n\n", report.Metadata.GeneratedAt.Local().Format("2006-01-02 15:04:05")))
 
-	for i, result := range r.Results {
-		if result.IsSuccessful {
-			passCount++
-			report.WriteString(fmt.Sprintf("Test #%d: PASS\n", i+1))
+	for i, rule := range report.Rules {
+		if rule.Passed {
+			out.WriteString(fmt.Sprintf("Test #%d%s: PASS\n", i+1, rule.label()))
 		} else {
-			failCount++
-			report.WriteString(fmt.Sprintf("Test #%d: FAIL\n", i+1))
-			report.WriteString("Failing Types:\n")
+			out.WriteString(fmt.Sprintf("Test #%d%s: FAIL%s\n", i+1, rule.label(), rule.severityLabel()))
+		}
+		writeTextDescription(&out, rule.Description)
+		if rule.Pattern != "" {
+			out.WriteString(fmt.Sprintf("Pattern: %s\n", rule.group()))
+		}
+		if rule.Error != "" {
+			out.WriteString(fmt.Sprintf("Invalid rule: %s\n", rule.Error))
+		}
+		writeTextWarnings(&out, rule.Warnings)
+		writeTextExemptions(&out, rule.Exemptions)
+		if rule.FrozenCount > 0 {
+			out.WriteString(fmt.Sprintf("Frozen: %d violation(s) in baseline\n", rule.FrozenCount))
+		}
+		if rule.Passed {
+			continue
+		}
 
-			for _, failingType := range result.FailingTypes {
-				report.WriteString(fmt.Sprintf("  - %s in package %s\n", failingType.Name, failingType.Package))
+		if len(rule.Violations) > 0 {
+			out.WriteString("Failing Types:\n")
+		}
+		for _, failingType := range failingTypes(rule.Violations) {
+			out.WriteString(fmt.Sprintf("  - %s in package %s\n", failingType.Type, failingType.Package))
+			for _, reason := range failingType.Reasons {
+				out.WriteString(fmt.Sprintf("      %s\n", reason))
 			}
-
-			report.WriteString("\n")
 		}
+
+		out.WriteString("\n")
+	}
+
+	out.WriteString(fmt.Sprintf("\nSummary: %d passed, %d failed\n", report.Summary.Passed, report.Summary.Failed))
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// writeTextDescription appends the rule description to a text report
+func writeTextDescription(report *strings.Builder, description string) {
+	if description != "" {
+		report.WriteString(fmt.Sprintf("Rule: %s\n", description))
 	}
+}
 
-	report.WriteString(fmt.Sprintf("\nSummary: %d passed, %d failed\n", passCount, failCount))
+// writeTextWarnings appends the warnings of a result to a text report
+func writeTextWarnings(report *strings.Builder, warnings []string) {
+	for _, warning := range warnings {
+		report.WriteString(fmt.Sprintf("Warning: %s\n", warning))
+	}
+}
 
-	return report.String()
+// writeTextExemptions appends the types excluded from a result's rule to a text report
+func writeTextExemptions(report *strings.Builder, exemptions []ExemptionReport) {
+	for _, exemption := range exemptions {
+		report.WriteString(fmt.Sprintf("Exempted: %s in package %s (%s)\n", exemption.Type, exemption.Package, exemption.Reason))
+	}
 }
 
 // GenerateHTMLReport generates an HTML report
@@ -71,9 +136,18 @@ func (r *Reporter) GenerateTextReport() string {
 // including the number of passed and failed tests,
 // and details about any failing types in a visually appealing format.
 func (r *Reporter) GenerateHTMLReport() string {
-	var report strings.Builder
+	report, _ := r.render(HTMLFormatter{})
+	return report
+}
 
-	report.WriteString(`<!DOCTYPE html>
+// HTMLFormatter writes reports as a standalone HTML page, registered as "html"
+type HTMLFormatter struct{}
+
+// Format writes the report as HTML
+func (HTMLFormatter) Format(w io.Writer, report *Report) error {
+	var out strings.Builder
+
+	out.WriteString(`<!DOCTYPE html>
 <html>
 <head>
     <title>GoArchTest Report</title>
@@ -113,69 +187,181 @@ func (r *Reporter) GenerateHTMLReport() string {
             margin-top: 10px;
             margin-left: 20px;
         }
+        .description {
+            font-style: italic;
+        }
+        .pattern {
+            color: #555;
+        }
+        .warning {
+            margin-top: 10px;
+            color: #8a6d3b;
+        }
+        .exemption {
+            margin-top: 10px;
+            color: #555;
+        }
     </style>
 </head>
 <body>
     <h1>GoArchTest Report</h1>
     <p>Date: `)
 
-	report.WriteString(time.Now().Format("2006-01-02 15:04:05"))
-	report.WriteString(`</p>`)
+	out.WriteString(report.Metadata.GeneratedAt.Local().Format("2006-01-02 15:04:05"))
+	out.WriteString(`</p>`)
 
-	passCount := 0
-	failCount := 0
-
-	for i, result := range r.Results {
-		if result.IsSuccessful {
-			passCount++
-			report.WriteString(fmt.Sprintf(`
+	for i, rule := range report.Rules {
+		if rule.Passed {
+			out.WriteString(fmt.Sprintf(`
     <div class="test pass">
-        <div class="test-title">Test #%d: PASS</div>
-    </div>`, i+1))
+        <div class="test-title">Test #%d%s: PASS</div>`, i+1, html.EscapeString(rule.label())))
 		} else {
-			failCount++
-			report.WriteString(fmt.Sprintf(`
+			out.WriteString(fmt.Sprintf(`
     <div class="test fail">
-        <div class="test-title">Test #%d: FAIL</div>
+        <div class="test-title">Test #%d%s: FAIL%s</div>`, i+1, html.EscapeString(rule.label()), html.EscapeString(rule.severityLabel())))
+		}
+		writeHTMLDescription(&out, rule.Description)
+		if rule.Pattern != "" {
+			out.WriteString(fmt.Sprintf(`
+        <div class="pattern">Pattern: %s</div>`, html.EscapeString(rule.group())))
+		}
+		if rule.Error != "" {
+			out.WriteString(fmt.Sprintf(`
+        <div class="warning">Invalid rule: %s</div>`, html.EscapeString(rule.Error)))
+		}
+		writeHTMLWarnings(&out, rule.Warnings)
+		writeHTMLExemptions(&out, rule.Exemptions)
+		if rule.FrozenCount > 0 {
+			out.WriteString(fmt.Sprintf(`
+        <div class="exemption">Frozen: %d violation(s) in baseline</div>`, rule.FrozenCount))
+		}
+
+		if !rule.Passed && len(rule.Violations) > 0 {
+			out.WriteString(`
         <div class="failing-types">
             <strong>Failing Types:</strong>
-            <ul>`, i+1))
+            <ul>`)
 
-			for _, failingType := range result.FailingTypes {
-				report.WriteString(fmt.Sprintf(`
-                <li>%s in package %s</li>`, failingType.Name, failingType.Package))
+			for _, failingType := range failingTypes(rule.Violations) {
+				out.WriteString(fmt.Sprintf(`
+                <li>%s in package %s`, html.EscapeString(failingType.Type), html.EscapeString(failingType.Package)))
+				writeHTMLReasons(&out, failingType.Reasons)
+				out.WriteString(`</li>`)
 			}
 
-			report.WriteString(`
+			out.WriteString(`
             </ul>
-        </div>
-    </div>`)
+        </div>`)
 		}
+
+		out.WriteString(`
+    </div>`)
 	}
 
-	report.WriteString(fmt.Sprintf(`
+	out.WriteString(fmt.Sprintf(`
     <div class="summary">
         <strong>Summary:</strong> %d passed, %d failed
     </div>
 </body>
-</html>`, passCount, failCount))
+</html>`, report.Summary.Passed, report.Summary.Failed))
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// writeHTMLDescription appends the rule description to an HTML report
+func writeHTMLDescription(report *strings.Builder, description string) {
+	if description != "" {
+		report.WriteString(fmt.Sprintf(`
+        <div class="description">%s</div>`, html.EscapeString(description)))
+	}
+}
+
+// writeHTMLWarnings appends the warnings of a result to an HTML report
+func writeHTMLWarnings(report *strings.Builder, warnings []string) {
+	for _, warning := range warnings {
+		report.WriteString(fmt.Sprintf(`
+        <div class="warning">Warning: %s</div>`, html.EscapeString(warning)))
+	}
+}
 
-	return report.String()
+// writeHTMLReasons appends the reasons a failing type violated a rule to an HTML report
+func writeHTMLReasons(report *strings.Builder, reasons []string) {
+	if len(reasons) == 0 {
+		return
+	}
+
+	report.WriteString(`
+                    <ul>`)
+	for _, reason := range reasons {
+		report.WriteString(fmt.Sprintf(`
+                        <li>%s</li>`, html.EscapeString(reason)))
+	}
+	report.WriteString(`
+                    </ul>
+                `)
+}
+
+// writeHTMLExemptions appends the types excluded from a result's rule to an HTML report
+func writeHTMLExemptions(report *strings.Builder, exemptions []ExemptionReport) {
+	for _, exemption := range exemptions {
+		report.WriteString(fmt.Sprintf(`
+        <div class="exemption">Exempted: %s in package %s (%s)</div>`,
+			html.EscapeString(exemption.Type), html.EscapeString(exemption.Package), html.EscapeString(exemption.Reason)))
+	}
+}
+
+// failingType is a type violating a rule, with the reasons recorded for it
+type failingType struct {
+	Type    string
+	Package string
+	Reasons []string
+}
+
+// failingTypes groups violations by type, in the order the types first appear
+func failingTypes(violations []ViolationReport) []*failingType {
+	var types []*failingType
+	byPath := make(map[string]*failingType)
+	for _, v := range violations {
+		key := v.Path + "." + v.Type
+		t, ok := byPath[key]
+		if !ok {
+			t = &failingType{Type: v.Type, Package: v.Package}
+			byPath[key] = t
+			types = append(types, t)
+		}
+		if v.Reason != "" {
+			t.Reasons = append(t.Reasons, v.Reason)
+		}
+	}
+	return types
+}
+
+// label returns the rule ID in parentheses, or nothing when the rule has none
+func (rule *RuleReport) label() string {
+	if rule.ID == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", rule.ID)
+}
+
+// severityLabel returns the severity in brackets for rules of a pattern,
+// or nothing for results added with AddResult, which are always errors
+func (rule *RuleReport) severityLabel() string {
+	if rule.Pattern == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", rule.Severity.orDefault())
 }
 
 // SaveReport saves a report to a file
-// It allows the user to specify the type of report (text or HTML)
+// It allows the user to specify the type of report, by the name of a registered
+// Formatter: text, html, markdown, json, junit, sarif or a custom one,
 // and the output path where the report should be saved.
 func (r *Reporter) SaveReport(reportType string, outputPath string) error {
-	var content string
-
-	switch strings.ToLower(reportType) {
-	case "text":
-		content = r.GenerateTextReport()
-	case "html":
-		content = r.GenerateHTMLReport()
-	default:
-		return fmt.Errorf("unsupported report type: %s", reportType)
+	var content strings.Builder
+	if err := r.WriteReport(&content, reportType); err != nil {
+		return err
 	}
 
 	// Ensure the directory exists
@@ -185,5 +371,27 @@ func (r *Reporter) SaveReport(reportType string, outputPath string) error {
 	}
 
 	// Write the report to file
-	return os.WriteFile(outputPath, []byte(content), 0644)
+	return os.WriteFile(outputPath, []byte(content.String()), 0644)
+}
+
+// WriteReport writes a report to w with the Formatter registered under reportType
+//
+// Example:
+//
+//	if err := reporter.WriteReport(os.Stdout, "markdown"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (r *Reporter) WriteReport(w io.Writer, reportType string) error {
+	formatter, ok := LookupFormatter(reportType)
+	if !ok {
+		return fmt.Errorf("unsupported report type: %s", reportType)
+	}
+	return formatter.Format(w, r.Report())
+}
+
+// render formats the report of the results as a string
+func (r *Reporter) render(formatter Formatter) (string, error) {
+	var out strings.Builder
+	err := formatter.Format(&out, r.Report())
+	return out.String(), err
 }
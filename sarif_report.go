@@ -0,0 +1,317 @@
+package goarchtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+)
+
+// sarifVersion and sarifSchema identify the SARIF version written by GenerateSARIFReport
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// sarifLog is the root object of a SARIF file
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun is a single run of goarchtest
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+// sarifTool describes goarchtest and the rules it evaluated
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+// sarifDriver is the tool component that evaluated the rules
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+// sarifRule is the metadata of a rule
+type sarifRule struct {
+	ID                   string               `json:"id"`
+	ShortDescription     sarifMessage         `json:"shortDescription"`
+	FullDescription      *sarifMessage        `json:"fullDescription,omitempty"`
+	HelpURI              string               `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration   `json:"defaultConfiguration"`
+	Properties           *sarifRuleProperties `json:"properties,omitempty"`
+}
+
+// sarifConfiguration holds the severity of a rule
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+// sarifRuleProperties holds the goarchtest metadata of a rule with no SARIF equivalent
+type sarifRuleProperties struct {
+	Tags     []string `json:"tags,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+	DocLinks []string `json:"docLinks,omitempty"`
+}
+
+// sarifInvocation reports whether the rules could be evaluated
+type sarifInvocation struct {
+	ExecutionSuccessful            bool                `json:"executionSuccessful"`
+	ToolConfigurationNotifications []sarifNotification `json:"toolConfigurationNotifications,omitempty"`
+}
+
+// sarifNotification reports an invalid rule, or a rule selecting nothing
+type sarifNotification struct {
+	Level          string              `json:"level"`
+	Message        sarifMessage        `json:"message"`
+	AssociatedRule *sarifRuleReference `json:"associatedRule,omitempty"`
+}
+
+// sarifRuleReference refers to a rule of the driver
+type sarifRuleReference struct {
+	ID    string `json:"id"`
+	Index int    `json:"index"`
+}
+
+// sarifResult is a single violation
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+// sarifMessage is a plain text message
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifLocation locates a violation in the sources and in the model
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// sarifPhysicalLocation is a region of a source file
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+// sarifArtifactLocation is a source file; relative files are relative to the source root
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// sarifRegion is a position in a source file
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifLogicalLocation is the type violating a rule
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// GenerateSARIFReport generates a SARIF 2.1.0 report of the architecture test results,
+// for code scanning tools such as GitHub code scanning.
+//
+// Every rule becomes a SARIF rule, identified by its ID (or its description for
+// results added with AddResult), with its description, rationale, documentation
+// link and severity: error, warning or note for info rules. Every violation of a
+// failed rule becomes a result, located at the declaration of the type or at the
+// offending import. File positions are relative to BaseDir, which should be the
+// repository root. Invalid rules and rules selecting no types are reported as
+// tool configuration notifications.
+func (r *Reporter) GenerateSARIFReport() (string, error) {
+	return r.render(SARIFFormatter{})
+}
+
+// SARIFFormatter writes reports as SARIF 2.1.0, registered as "sarif".
+// See Reporter.GenerateSARIFReport for how rules and violations are mapped.
+type SARIFFormatter struct{}
+
+// Format writes the report as SARIF
+func (SARIFFormatter) Format(w io.Writer, report *Report) error {
+	data, err := json.MarshalIndent(report.sarif(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// SaveSARIF writes the SARIF 2.1.0 report of a set of validation results to a file.
+// See Reporter.GenerateSARIFReport for how rules and violations are mapped.
+//
+// Example:
+//
+//	results := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation").Validate(types)
+//	if err := goarchtest.SaveSARIF("goarchtest.sarif", results); err != nil {
+//	    t.Fatal(err)
+//	}
+func SaveSARIF(path string, results []*ValidationResult) error {
+	reporter := NewReporter()
+	reporter.AddValidationResults(results)
+	return reporter.SaveReport("sarif", path)
+}
+
+// sarif converts the report to a SARIF log with a single run
+func (report *Report) sarif() sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           report.Metadata.Tool,
+			InformationURI: "https://github.com/solrac97gr/goarchtest",
+			Rules:          []sarifRule{},
+		}},
+		Invocations: []sarifInvocation{{ExecutionSuccessful: true}},
+		Results:     []sarifResult{},
+	}
+	invocation := &run.Invocations[0]
+	index := make(map[string]int)
+
+	for _, rule := range report.Rules {
+		id := rule.ID
+		if id == "" {
+			id = rule.Description
+		}
+		level := sarifLevel(rule.Severity)
+
+		// Rules shared by several patterns are described once
+		i, ok := index[id]
+		if !ok {
+			i = len(run.Tool.Driver.Rules)
+			index[id] = i
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRuleOf(id, rule, level))
+		}
+		reference := &sarifRuleReference{ID: id, Index: i}
+
+		if rule.Error != "" {
+			invocation.ExecutionSuccessful = false
+			invocation.ToolConfigurationNotifications = append(invocation.ToolConfigurationNotifications, sarifNotification{
+				Level:          "error",
+				Message:        sarifMessage{Text: rule.Error},
+				AssociatedRule: reference,
+			})
+			continue
+		}
+		for _, warning := range rule.Warnings {
+			invocation.ToolConfigurationNotifications = append(invocation.ToolConfigurationNotifications, sarifNotification{
+				Level:          "warning",
+				Message:        sarifMessage{Text: warning},
+				AssociatedRule: reference,
+			})
+		}
+		if rule.Passed {
+			continue
+		}
+
+		// A failed rule without violations, such as an unmet existence
+		// requirement, is still reported, without a location
+		if len(rule.Violations) == 0 {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    id,
+				RuleIndex: i,
+				Level:     level,
+				Message:   sarifMessage{Text: rule.Description},
+			})
+		}
+		for _, v := range rule.Violations {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    id,
+				RuleIndex: i,
+				Level:     level,
+				Message:   sarifMessage{Text: sarifViolationMessage(rule.Description, v)},
+				Locations: []sarifLocation{sarifLocationOf(v)},
+			})
+		}
+	}
+
+	return sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+}
+
+// sarifRuleOf describes a rule
+func sarifRuleOf(id string, rule RuleReport, level string) sarifRule {
+	descriptor := sarifRule{
+		ID:                   id,
+		ShortDescription:     sarifMessage{Text: rule.Description},
+		DefaultConfiguration: sarifConfiguration{Level: level},
+	}
+	if rule.Rationale != "" {
+		descriptor.FullDescription = &sarifMessage{Text: rule.Rationale}
+	}
+	if len(rule.DocLinks) > 0 {
+		descriptor.HelpURI = rule.DocLinks[0]
+	}
+	if len(rule.Tags) > 0 || rule.Pattern != "" || len(rule.DocLinks) > 1 {
+		descriptor.Properties = &sarifRuleProperties{Tags: rule.Tags, Pattern: rule.Pattern}
+		if len(rule.DocLinks) > 1 {
+			descriptor.Properties.DocLinks = rule.DocLinks
+		}
+	}
+	return descriptor
+}
+
+// sarifLevel maps a severity to a SARIF level
+func sarifLevel(severity Severity) string {
+	switch severity.orDefault() {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// sarifViolationMessage describes a violation, with the reason when it is known
+func sarifViolationMessage(description string, v ViolationReport) string {
+	if v.Reason == "" {
+		return fmt.Sprintf("%s: %s in package %s", description, v.Type, v.Package)
+	}
+	return fmt.Sprintf("%s: %s in package %s (%s)", description, v.Type, v.Package, v.Reason)
+}
+
+// sarifLocationOf locates a violation at its position, if known, and at its type
+func sarifLocationOf(v ViolationReport) sarifLocation {
+	location := sarifLocation{
+		LogicalLocations: []sarifLogicalLocation{{
+			Name:               v.Type,
+			FullyQualifiedName: v.Path + "." + v.Type,
+			Kind:               "type",
+		}},
+	}
+	if v.Position == nil || !v.Position.IsValid() {
+		return location
+	}
+
+	artifact := sarifArtifactLocation{URIBaseID: "%SRCROOT%"}
+	file := filepath.ToSlash(v.Position.File)
+	if filepath.IsAbs(v.Position.File) {
+		if filepath.VolumeName(v.Position.File) != "" {
+			file = "/" + file
+		}
+		artifact = sarifArtifactLocation{URI: (&url.URL{Scheme: "file", Path: file}).String()}
+	} else {
+		artifact.URI = (&url.URL{Path: file}).String()
+	}
+
+	location.PhysicalLocation = &sarifPhysicalLocation{
+		ArtifactLocation: artifact,
+		Region:           sarifRegion{StartLine: v.Position.Line, StartColumn: v.Position.Column},
+	}
+	return location
+}
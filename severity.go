@@ -0,0 +1,76 @@
+package goarchtest
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Severity describes how serious a rule violation is
+type Severity string
+
+const (
+	// SeverityError marks violations that must fail the build. It is the default.
+	SeverityError Severity = "error"
+	// SeverityWarning marks violations that should be reported but not fail the build
+	SeverityWarning Severity = "warning"
+	// SeverityInfo marks purely informational rules
+	SeverityInfo Severity = "info"
+)
+
+// orDefault returns the severity, treating the zero value as SeverityError
+func (s Severity) orDefault() Severity {
+	if s == "" {
+		return SeverityError
+	}
+	return s
+}
+
+// IsBlocking reports whether the result is a failed rule with error severity.
+// Use it to fail CI only on errors while still reporting warnings.
+func (vr *ValidationResult) IsBlocking() bool {
+	return !vr.IsSuccessful && vr.Severity.orDefault() == SeverityError
+}
+
+// HasBlockingFailures reports whether any result is a failed rule with error severity
+//
+// Example:
+//
+//	results := pattern.Validate(types)
+//	if goarchtest.HasBlockingFailures(results) {
+//	    os.Exit(1)
+//	}
+func HasBlockingFailures(results []*ValidationResult) bool {
+	for _, result := range results {
+		if result.IsBlocking() {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupBySeverity groups validation results by the severity of their rule,
+// keeping the original order within each group
+func GroupBySeverity(results []*ValidationResult) map[Severity][]*ValidationResult {
+	groups := make(map[Severity][]*ValidationResult)
+	for _, result := range results {
+		severity := result.Severity.orDefault()
+		groups[severity] = append(groups[severity], result)
+	}
+	return groups
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slug converts text into a lowercase, dash-separated identifier
+func slug(text string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(text), "-"), "-")
+}
+
+// ruleID builds a rule identifier from a pattern prefix and descriptive parts
+func ruleID(prefix string, parts ...string) string {
+	slugs := make([]string, 0, len(parts))
+	for _, part := range parts {
+		slugs = append(slugs, slug(part))
+	}
+	return prefix + "/" + strings.Join(slugs, "-")
+}
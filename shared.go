@@ -0,0 +1,124 @@
+package goarchtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// sharedModels memoizes the models loaded through Shared, by path and options
+var sharedModels = struct {
+	sync.Mutex
+	entries map[string]*sharedModel
+}{entries: make(map[string]*sharedModel)}
+
+// sharedModel is a model loaded at most once, by the first caller
+type sharedModel struct {
+	once  sync.Once
+	types *Types
+}
+
+// Shared returns the model of the packages in path, loading it once per process
+// for each combination of path and options.
+//
+// Loading type-checks the whole module, so suites that split their rules across
+// many test functions should share the model instead of calling InPath in each
+// of them. Shared is safe for concurrent use, including from parallel tests:
+// concurrent callers wait for the first load and receive the same model.
+// The model itself is read-only, so its chains can be evaluated concurrently.
+//
+// Models are shared between calls whose options load the same model and
+// evaluate rules the same way: the load mode, build flags, cache settings,
+// strict mode and empty selection policy are compared by value, and the
+// Registry by identity, so pass the same Registry to share a model using it.
+// The load timeout is ignored. Options with a WithProgress callback are never
+// shared, since the callback would only observe the first load: every such
+// call loads a model of its own.
+//
+// Example:
+//
+//	func TestDomain(t *testing.T) {
+//	    t.Parallel()
+//	    types := goarchtest.Shared("../")
+//	    result := types.That().ResideInNamespace("domain").ShouldNot().HaveDependencyOn("infrastructure").GetResult()
+//	    archtesting.AssertRule(t, result)
+//	}
+func Shared(path string, opts ...Option) *Types {
+	key, ok := sharedKey(path, opts)
+	if !ok {
+		return InPath(path, opts...)
+	}
+
+	sharedModels.Lock()
+	entry, ok := sharedModels.entries[key]
+	if !ok {
+		entry = &sharedModel{}
+		sharedModels.entries[key] = entry
+	}
+	sharedModels.Unlock()
+
+	entry.once.Do(func() {
+		entry.types = InPath(path, opts...)
+		entry.types.sharedKey = key
+	})
+	return entry.types
+}
+
+// Reload loads the model again, with the path and options it was loaded with,
+// and returns the new model. Models previously returned are left untouched.
+// If the model was returned by Shared, the reloaded model replaces it, so that
+// subsequent calls to Shared return the reloaded one.
+//
+// Example:
+//
+//	types := goarchtest.Shared("./")
+//	// ... the sources change ...
+//	types = types.Reload()
+func (t *Types) Reload() *Types {
+	types := t.reload()
+	if t.sharedKey == "" {
+		return types
+	}
+
+	types.sharedKey = t.sharedKey
+	entry := &sharedModel{types: types}
+	entry.once.Do(func() {})
+
+	sharedModels.Lock()
+	sharedModels.entries[t.sharedKey] = entry
+	sharedModels.Unlock()
+	return types
+}
+
+// reload loads the model again, from its directory or from its sources
+func (t *Types) reload() *Types {
+	if t.sources == nil {
+		return InPath(t.path, t.opts...)
+	}
+
+	types, err := FromSources(t.sources, t.opts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load packages: %v\n", err)
+		types = emptyTypes(newConfig(t.opts))
+		types.sources, types.opts = t.sources, t.opts
+	}
+	return types
+}
+
+// sharedKey identifies a model by its absolute path and the settings of its
+// options that change the model or how its rules are evaluated. It reports
+// false for options with a progress callback, which cannot be shared.
+func sharedKey(path string, opts []Option) (string, bool) {
+	cfg := newConfig(opts)
+	if cfg.progress != nil {
+		return "", false
+	}
+
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return fmt.Sprintf("%s\x00mode=%d\x00flags=%q\x00cache=%t:%s\x00strict=%t\x00empty=%d:%t\x00registry=%p",
+		path, cfg.loadMode, cfg.buildFlags, cfg.cache, cfg.cacheDir,
+		cfg.strict, cfg.emptySelection, cfg.emptySelectionSet, cfg.registry), true
+}
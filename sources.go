@@ -0,0 +1,97 @@
+package goarchtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// sourcesModule is the module declared for sources given without a go.mod
+const sourcesModule = "app"
+
+// FromSources creates a new Types instance from in-memory sources, like InPath
+// does for a directory. It lets rule authors test their rules and patterns
+// with table-driven tests instead of fixture modules.
+//
+// The keys are slash-separated file paths relative to the module root, and the
+// values the file contents. Unless a "go.mod" is given, the sources form a
+// module named "app", so that the package in "domain/" is imported as "app/domain".
+//
+// The sources are handed to the go command as an overlay: only an empty
+// temporary directory is created, and removed once the sources are loaded.
+// Since sources are expected to compile, packages with errors are reported
+// as an error rather than left out of the model. With LoadImports, the sources
+// are only parsed, so syntax errors are reported but type errors are not.
+// The model cache is not used.
+//
+// Example:
+//
+//	types, err := goarchtest.FromSources(map[string]string{
+//	    "domain/user.go":         "package domain\n\ntype User struct{}\n",
+//	    "infrastructure/repo.go": "package infrastructure\n\nimport \"app/domain\"\n\ntype UserRepository struct{ users []domain.User }\n",
+//	})
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//
+//	result := types.That().ResideInNamespace("domain").ShouldNot().HaveDependencyOn("infrastructure").GetResult()
+func FromSources(files map[string]string, opts ...Option) (*Types, error) {
+	dir, err := os.MkdirTemp("", "goarchtest-sources")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	overlay := make(map[string][]byte, len(files)+1)
+	for name, content := range files {
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			return nil, fmt.Errorf("source path %q must be relative to the module root", name)
+		}
+		overlay[filepath.Join(dir, filepath.FromSlash(name))] = []byte(content)
+	}
+	if _, ok := files["go.mod"]; !ok {
+		overlay[filepath.Join(dir, "go.mod")] = []byte("module " + sourcesModule + "\n\ngo 1.24\n")
+	}
+
+	options := newConfig(opts)
+	options.overlay = overlay
+	options.cache = false
+
+	types, err := loadWithin(context.Background(), dir, options)
+	if err == nil {
+		err = packageErrors(types)
+	}
+	if err != nil {
+		// Report the file paths as given, not in the temporary directory
+		return nil, errors.New(strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), ""))
+	}
+
+	// Positions are reported relative to the module root, as the sources were given
+	for _, t := range types.typeSet.types {
+		t.Position = t.Position.relativeTo(dir)
+		for _, positions := range t.importPositions {
+			for i := range positions {
+				positions[i] = positions[i].relativeTo(dir)
+			}
+		}
+	}
+
+	types.sources, types.opts = files, opts
+	return types, nil
+}
+
+// packageErrors joins the errors of the loaded packages, or of the parsed
+// packages when the model was loaded without type information
+func packageErrors(types *Types) error {
+	errs := slices.Clone(types.loadErrors)
+	for _, pkg := range types.pkgs {
+		for _, err := range pkg.Errors {
+			errs = append(errs, fmt.Errorf("%s: %s", pkg.PkgPath, err))
+		}
+	}
+	return errors.Join(errs...)
+}
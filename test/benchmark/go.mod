@@ -0,0 +1,13 @@
+module github.com/solrac97gr/goarchtest/test/benchmark
+
+go 1.24.1
+
+replace github.com/solrac97gr/goarchtest => ../..
+
+require github.com/solrac97gr/goarchtest v0.0.0-00010101000000-000000000000
+
+require (
+	golang.org/x/mod v0.25.0 // indirect
+	golang.org/x/sync v0.15.0 // indirect
+	golang.org/x/tools v0.34.0 // indirect
+)
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/solrac97gr/goarchtest"
+)
+
+// Size of the synthetic module: every bounded context has one package per layer
+const (
+	contexts        = 50
+	typesPerPackage = 20
+)
+
+var layers = []string{"domain", "application", "infrastructure", "presentation"}
+
+var (
+	loadOnce   sync.Once
+	moduleDir  string
+	largeTypes *goarchtest.Types
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if moduleDir != "" {
+		os.RemoveAll(moduleDir)
+	}
+	os.Exit(code)
+}
+
+// load generates the synthetic module once and analyzes it
+func load(tb testing.TB) *goarchtest.Types {
+	tb.Helper()
+
+	loadOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "goarchtest-benchmark")
+		if err != nil {
+			tb.Fatalf("Failed to create module directory: %v", err)
+		}
+		moduleDir = dir
+
+		if err := generateModule(dir); err != nil {
+			tb.Fatalf("Failed to generate module: %v", err)
+		}
+		largeTypes = goarchtest.InPath(dir)
+	})
+
+	if largeTypes == nil {
+		tb.Fatal("Synthetic module failed to load")
+	}
+	return largeTypes
+}
+
+// generateModule writes a module with a shared kernel and bounded contexts whose
+// layers import the inner layers of the same context and the shared kernel
+func generateModule(dir string) error {
+	files := map[string]string{
+		"go.mod":          "module example.com/large\n\ngo 1.24\n",
+		"shared/types.go": "package shared\n\n// ID identifies entities\ntype ID string\n",
+	}
+
+	for c := 0; c < contexts; c++ {
+		for l, layer := range layers {
+			var source strings.Builder
+			fmt.Fprintf(&source, "package %s\n\nimport (\n\t\"example.com/large/shared\"\n", layer)
+			for _, inner := range layers[:l] {
+				fmt.Fprintf(&source, "\t%s \"example.com/large/internal/ctx%d/%s\"\n", inner, c, inner)
+			}
+			source.WriteString(")\n")
+
+			for i := 0; i < typesPerPackage; i++ {
+				fmt.Fprintf(&source, "\n// %s%d is a generated type\ntype %s%d struct {\n\tID shared.ID\n", typeName(layer), i, typeName(layer), i)
+				for _, inner := range layers[:l] {
+					fmt.Fprintf(&source, "\t%s *%s.%s%d\n", typeName(inner), inner, typeName(inner), i)
+				}
+				source.WriteString("}\n")
+			}
+
+			files[fmt.Sprintf("internal/ctx%d/%s/types.go", c, layer)] = source.String()
+		}
+	}
+
+	return writeFiles(dir, files)
+}
+
+// writeFiles writes the files, given by path relative to dir
+func writeFiles(dir string, files map[string]string) error {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeName returns the type name prefix used in a layer
+func typeName(layer string) string {
+	return map[string]string{
+		"domain":         "Entity",
+		"application":    "Service",
+		"infrastructure": "Repository",
+		"presentation":   "Handler",
+	}[layer]
+}
+
+func TestSyntheticModule(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping synthetic module in short mode")
+	}
+
+	types := load(t)
+
+	if got, expected := len(types.That().GetAllTypes()), contexts*len(layers)*typesPerPackage+1; got != expected {
+		t.Fatalf("Expected %d types, got %d", expected, got)
+	}
+
+	result := types.That().
+		ResideInNamespace("internal/*/domain").
+		ShouldNot().
+		HaveDependencyOn("internal/*/infrastructure").
+		GetResult()
+	if !result.IsSuccessful {
+		t.Errorf("Expected the domain layers not to depend on infrastructure: %d violations", len(result.Violations))
+	}
+
+	result = types.That().
+		ResideInNamespace("internal/*/presentation").
+		ShouldNot().
+		HaveDependencyOn("internal/*/domain").
+		GetResult()
+	if result.IsSuccessful || len(result.FailingTypes) != contexts*typesPerPackage {
+		t.Errorf("Expected every presentation type to depend on the domain, got %d", len(result.FailingTypes))
+	}
+}
+
+func TestModelCache(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := t.TempDir()
+	err := writeFiles(dir, map[string]string{
+		"go.mod":                       "module example.com/cached\n\ngo 1.24\n",
+		"domain/user.go":               "package domain\n\ntype User struct{}\n\ntype Repository interface {\n\tSave(User) error\n}\n",
+		"application/service.go":       "package application\n\nimport \"example.com/cached/domain\"\n\ntype Service struct {\n\tRepo domain.Repository\n}\n",
+		"infrastructure/repository.go": "package infrastructure\n\nimport \"example.com/cached/domain\"\n\ntype UserRepository struct{}\n\nfunc (UserRepository) Save(domain.User) error { return nil }\n",
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate module: %v", err)
+	}
+
+	uncached := goarchtest.InPath(dir)
+	if uncached.CacheStats().Enabled {
+		t.Error("Expected the cache to be disabled by default")
+	}
+
+	// The cache cannot serve the type information of the full load mode
+	if _, err := goarchtest.LoadContext(context.Background(), dir, goarchtest.WithCacheDir(cacheDir)); !errors.Is(err, goarchtest.ErrCacheRequiresLoadImports) {
+		t.Errorf("Expected the cache without LoadImports to be rejected, got %v", err)
+	}
+
+	// The first run analyzes every package and fills the cache
+	first := goarchtest.InPath(dir, goarchtest.WithCacheDir(cacheDir), goarchtest.WithLoadMode(goarchtest.LoadImports))
+	if stats := first.CacheStats(); !stats.Enabled || stats.Hits != 0 || stats.Misses != 3 {
+		t.Errorf("Expected 3 misses on the first run, got %+v", stats)
+	}
+	if _, err := os.Stat(first.CacheStats().Path); err != nil {
+		t.Errorf("Expected the cache file to be written: %v", err)
+	}
+	assertSameModel(t, uncached, first)
+
+	// The second run restores every package
+	second := goarchtest.InPath(dir, goarchtest.WithCacheDir(cacheDir), goarchtest.WithLoadMode(goarchtest.LoadImports))
+	if stats := second.CacheStats(); stats.Hits != 3 || stats.Misses != 0 {
+		t.Errorf("Expected 3 hits on the second run, got %+v", stats)
+	}
+	assertSameModel(t, uncached, second)
+
+	if importers := second.ImportersOf("example.com/cached/domain"); len(importers) != 2 {
+		t.Errorf("Expected the domain to have 2 importers, got %v", importers)
+	}
+	result := second.That().
+		ResideInNamespace("domain").
+		ShouldNot().
+		HaveDependencyOn("infrastructure").
+		GetResult()
+	if !result.IsSuccessful {
+		t.Error("Expected the domain not to depend on infrastructure")
+	}
+
+	// Only the changed package is re-analyzed
+	err = writeFiles(dir, map[string]string{
+		"domain/order.go": "package domain\n\ntype Order struct{}\n",
+	})
+	if err != nil {
+		t.Fatalf("Failed to change module: %v", err)
+	}
+
+	third := goarchtest.InPath(dir, goarchtest.WithCacheDir(cacheDir), goarchtest.WithLoadMode(goarchtest.LoadImports))
+	if stats := third.CacheStats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss after the change, got %+v", stats)
+	}
+	assertSameModel(t, goarchtest.InPath(dir), third)
+
+	// Build flags are part of the cache key
+	tagged := goarchtest.InPath(dir, goarchtest.WithCacheDir(cacheDir), goarchtest.WithLoadMode(goarchtest.LoadImports), goarchtest.WithBuildFlags("-tags=integration"))
+	if stats := tagged.CacheStats(); stats.Path == third.CacheStats().Path || stats.Misses != 3 {
+		t.Errorf("Expected build flags to select another cache, got %+v", stats)
+	}
+}
+
+// assertSameModel compares the types of a model loaded without the cache to a cached one
+func assertSameModel(t *testing.T, expected, actual *goarchtest.Types) {
+	t.Helper()
+
+	describe := func(types *goarchtest.Types) []string {
+		var descriptions []string
+		for _, typ := range types.That().GetAllTypes() {
+			descriptions = append(descriptions, fmt.Sprintf("%s.%s struct=%t interface=%t methods=%v imports=%v at=%s",
+				typ.FullPath, typ.Name, typ.IsStruct, typ.IsInterface, typ.Interfaces, typ.Imports, typ.Position))
+			for _, imp := range typ.Imports {
+				descriptions = append(descriptions, fmt.Sprintf("  imports %s at %s", imp, typ.ImportPosition(imp)))
+			}
+		}
+		return descriptions
+	}
+
+	if got, want := strings.Join(describe(actual), "\n"), strings.Join(describe(expected), "\n"); got != want {
+		t.Errorf("Cached model differs from the loaded one:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func BenchmarkLoad(b *testing.B) {
+	load(b)
+	b.ResetTimer()
+
+	for b.Loop() {
+		goarchtest.InPath(moduleDir)
+	}
+}
+
+func BenchmarkLoadImports(b *testing.B) {
+	load(b)
+	b.ResetTimer()
+
+	for b.Loop() {
+		goarchtest.InPath(moduleDir, goarchtest.WithLoadMode(goarchtest.LoadImports))
+	}
+}
+
+func BenchmarkLoadCached(b *testing.B) {
+	load(b)
+	cacheDir := b.TempDir()
+
+	// Warm the cache, so that every iteration restores the whole module
+	goarchtest.InPath(moduleDir, goarchtest.WithCacheDir(cacheDir), goarchtest.WithLoadMode(goarchtest.LoadImports))
+	b.ResetTimer()
+
+	for b.Loop() {
+		goarchtest.InPath(moduleDir, goarchtest.WithCacheDir(cacheDir), goarchtest.WithLoadMode(goarchtest.LoadImports))
+	}
+}
+
+func BenchmarkResideInNamespace(b *testing.B) {
+	types := load(b)
+	b.ResetTimer()
+
+	for b.Loop() {
+		types.That().ResideInNamespace("internal/*/domain").GetAllTypes()
+	}
+}
+
+func BenchmarkHaveDependencyOn(b *testing.B) {
+	types := load(b)
+	b.ResetTimer()
+
+	for b.Loop() {
+		types.That().HaveDependencyOn("infrastructure").GetAllTypes()
+	}
+}
+
+func BenchmarkNegatedRule(b *testing.B) {
+	types := load(b)
+	b.ResetTimer()
+
+	for b.Loop() {
+		types.That().
+			ResideInNamespace("domain").
+			ShouldNot().
+			HaveDependencyOn("infrastructure").
+			GetResult()
+	}
+}
+
+func BenchmarkPositiveRule(b *testing.B) {
+	types := load(b)
+	b.ResetTimer()
+
+	// Every selected type fails, which exercises the set difference in GetResult
+	for b.Loop() {
+		types.That().
+			ResideInNamespace("application").
+			Should().
+			HaveNameEndingWith("Repository").
+			GetResult()
+	}
+}
+
+func BenchmarkOr(b *testing.B) {
+	types := load(b)
+	b.ResetTimer()
+
+	for b.Loop() {
+		types.That().
+			ResideInNamespace("domain").
+			Or(types.That().ResideInNamespace("application")).
+			GetAllTypes()
+	}
+}
+
+func BenchmarkValidateDDD(b *testing.B) {
+	types := load(b)
+
+	domains := make([]string, 15)
+	for i := range domains {
+		domains[i] = fmt.Sprintf("ctx%d", i)
+	}
+	pattern := goarchtest.DDDArchitecture(domains, goarchtest.WithDDDLayers(layers...))
+	b.ResetTimer()
+
+	for b.Loop() {
+		pattern.Validate(types)
+	}
+}
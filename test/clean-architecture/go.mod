@@ -4,10 +4,14 @@ go 1.24.1
 
 replace github.com/solrac97gr/goarchtest => ../..
 
-require github.com/solrac97gr/goarchtest v0.0.0-00010101000000-000000000000
+require (
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2
+	github.com/solrac97gr/goarchtest v0.0.0-00010101000000-000000000000
+)
 
 require (
 	golang.org/x/mod v0.25.0 // indirect
 	golang.org/x/sync v0.15.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
 	golang.org/x/tools v0.34.0 // indirect
 )
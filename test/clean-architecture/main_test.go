@@ -1,10 +1,22 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/solrac97gr/goarchtest"
+	"github.com/solrac97gr/goarchtest/archtesting"
 )
 
 func TestCleanArchitecture(t *testing.T) {
@@ -42,3 +54,1052 @@ func TestCleanArchitecture(t *testing.T) {
 		}
 	}
 }
+
+// TestEmptySelection tests that rules whose selection matches nothing are detected
+func TestEmptySelection(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	t.Run("Selected and evaluated types are counted", func(t *testing.T) {
+		result := goarchtest.InPath(projectPath).
+			That().
+			ResideInNamespace("domain").
+			ShouldNot().
+			HaveDependencyOn("infrastructure").
+			GetResult()
+
+		if !result.IsSuccessful {
+			t.Fatalf("Domain should not depend on infrastructure: %v", result.FailingTypes)
+		}
+		if result.SelectedCount == 0 || result.EvaluatedCount != result.SelectedCount {
+			t.Errorf("Expected domain types to be selected and evaluated, got %d selected and %d evaluated",
+				result.SelectedCount, result.EvaluatedCount)
+		}
+	})
+
+	t.Run("Vacuous rules pass by default", func(t *testing.T) {
+		result := goarchtest.InPath(projectPath).
+			That().
+			ResideInNamespace("transport").
+			ShouldNot().
+			HaveDependencyOn("infrastructure").
+			GetResult()
+
+		if !result.IsSuccessful || len(result.Warnings) != 0 {
+			t.Errorf("Expected a silent pass without a policy, got %v", result.Warnings)
+		}
+	})
+
+	t.Run("Vacuous rules fail in strict mode", func(t *testing.T) {
+		result := goarchtest.InPath(projectPath, goarchtest.WithStrictMode()).
+			That().
+			ResideInNamespace("transport").
+			ShouldNot().
+			HaveDependencyOn("infrastructure").
+			GetResult()
+
+		if result.IsSuccessful {
+			t.Error("Expected the vacuous rule to fail in strict mode")
+		}
+		if result.SelectedCount != 0 || len(result.Warnings) == 0 {
+			t.Errorf("Expected an empty selection to be reported, got %d selected and warnings %v",
+				result.SelectedCount, result.Warnings)
+		}
+	})
+
+	t.Run("Reused selections stay on the selection side", func(t *testing.T) {
+		types := goarchtest.InPath(projectPath)
+		domain := types.That().ResideInNamespace("domain")
+		domain.ShouldNot().HaveDependencyOn("infrastructure").GetResult()
+
+		structs := len(types.That().ResideInNamespace("domain").BeStruct().GetAllTypes())
+		result := domain.BeStruct().ShouldNot().HaveDependencyOn("presentation").GetResult()
+
+		if result.SelectedCount != structs || !strings.Contains(result.Description, "are structs") {
+			t.Errorf("Expected BeStruct to narrow the reused selection to %d structs, got %d selected in %q",
+				structs, result.SelectedCount, result.Description)
+		}
+	})
+
+	t.Run("Vacuous rules warn when configured", func(t *testing.T) {
+		types := goarchtest.InPath(projectPath,
+			goarchtest.WithStrictMode(),
+			goarchtest.WithEmptySelectionPolicy(goarchtest.EmptySelectionWarn))
+
+		result := types.That().
+			ResideInNamespace("transport").
+			ShouldNot().
+			HaveDependencyOn("infrastructure").
+			GetResult()
+
+		if !result.IsSuccessful || len(result.Warnings) == 0 {
+			t.Errorf("Expected the vacuous rule to pass with a warning, got success=%v warnings=%v",
+				result.IsSuccessful, result.Warnings)
+		}
+	})
+}
+
+// TestRuleMetadata tests that rule identity and metadata are carried into validation results
+func TestRuleMetadata(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+
+	t.Run("Predefined patterns have stable IDs and error severity", func(t *testing.T) {
+		results := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation").Validate(types)
+
+		seen := make(map[string]bool)
+		for _, result := range results {
+			if result.RuleID == "" || seen[result.RuleID] {
+				t.Errorf("Expected a unique rule ID, got %q", result.RuleID)
+			}
+			seen[result.RuleID] = true
+
+			if result.Severity != goarchtest.SeverityError {
+				t.Errorf("Expected %s to have error severity, got %q", result.RuleID, result.Severity)
+			}
+			if len(result.Tags) == 0 || result.Rationale == "" || len(result.DocLinks) == 0 {
+				t.Errorf("Expected %s to carry tags, rationale and documentation links", result.RuleID)
+			}
+		}
+
+		if !seen["clean-architecture/domain-not-depend-on-infrastructure"] {
+			t.Errorf("Expected the domain/infrastructure rule ID, got %v", seen)
+		}
+	})
+
+	t.Run("Only error severity failures are blocking", func(t *testing.T) {
+		pattern := &goarchtest.ArchitecturePattern{
+			Name: "Conventions",
+			Rules: []goarchtest.Rule{
+				{
+					Description: "Application types should live in the presentation layer",
+					Severity:    goarchtest.SeverityWarning,
+					Tags:        []string{"naming"},
+					Validate: func(types *goarchtest.Types) *goarchtest.Result {
+						return types.That().
+							ResideInNamespace("application").
+							Should().
+							ResideInNamespace("presentation").
+							GetResult()
+					},
+				},
+			},
+		}
+
+		results := pattern.Validate(types)
+		if results[0].IsSuccessful {
+			t.Fatal("Expected the convention rule to fail")
+		}
+		if results[0].RuleID != "conventions/application-types-should-live-in-the-presentation-layer" {
+			t.Errorf("Expected an ID derived from the description, got %q", results[0].RuleID)
+		}
+		if goarchtest.HasBlockingFailures(results) {
+			t.Error("Expected a failed warning rule not to be blocking")
+		}
+		if len(goarchtest.GroupBySeverity(results)[goarchtest.SeverityWarning]) != 1 {
+			t.Error("Expected the result to be grouped under warnings")
+		}
+	})
+}
+
+// recordingT captures the failures reported by the archtesting helpers
+type recordingT struct {
+	testing.TB
+	errors []string
+	logs   []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Error(args ...any) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recordingT) Logf(format string, args ...any) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+// TestArchtesting tests the testing.T integration helpers
+func TestArchtesting(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+
+	t.Run("Pattern rules run as subtests", func(t *testing.T) {
+		cleanArch := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation")
+		archtesting.AssertPattern(t, cleanArch, types)
+	})
+
+	t.Run("Failed warnings are logged", func(t *testing.T) {
+		pattern := &goarchtest.ArchitecturePattern{
+			Name: "Conventions",
+			Rules: []goarchtest.Rule{
+				{
+					ID:          "conventions/no-services",
+					Description: "Application should not contain services",
+					Severity:    goarchtest.SeverityWarning,
+					Validate: func(types *goarchtest.Types) *goarchtest.Result {
+						return types.That().
+							ResideInNamespace("application").
+							ShouldNot().
+							HaveNameEndingWith("Service").
+							GetResult()
+					},
+				},
+			},
+		}
+
+		if !archtesting.AssertPattern(t, pattern, types) {
+			t.Error("Expected a failed warning not to fail the pattern")
+		}
+	})
+
+	t.Run("Failed rules report their violations", func(t *testing.T) {
+		recorder := &recordingT{TB: t}
+		passed := archtesting.AssertRule(recorder, types.That().
+			ResideInNamespace("infrastructure").
+			ShouldNot().
+			HaveDependencyOn("domain").
+			GetResult())
+
+		if passed || len(recorder.errors) != 1 {
+			t.Fatalf("Expected one reported failure, got %v", recorder.errors)
+		}
+		if !strings.Contains(recorder.errors[0], "imports github.com/solrac97gr/goarchtest/test/clean_architecture/domain") {
+			t.Errorf("Expected the failure to include the evidence, got %s", recorder.errors[0])
+		}
+	})
+
+	t.Run("Passing rules report nothing", func(t *testing.T) {
+		recorder := &recordingT{TB: t}
+		passed := archtesting.AssertRule(recorder, types.That().
+			ResideInNamespace("domain").
+			ShouldNot().
+			HaveDependencyOn("infrastructure").
+			GetResult())
+
+		if !passed || len(recorder.errors) != 0 {
+			t.Errorf("Expected the rule to pass silently, got %v", recorder.errors)
+		}
+	})
+}
+
+// TestPatternComposition tests composing patterns from rules and other patterns
+func TestPatternComposition(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	cleanArch := goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation")
+	ruleCount := len(cleanArch.Rules)
+
+	servicesInApplication := goarchtest.Rule{
+		ID:          "conventions/services-in-application",
+		Description: "Services should reside in the application layer",
+		Validate: func(types *goarchtest.Types) *goarchtest.Result {
+			return types.That().HaveNameEndingWith("Service").Should().ResideInNamespace("application").GetResult()
+		},
+	}
+
+	t.Run("With and Without add and remove rules", func(t *testing.T) {
+		composed := cleanArch.
+			With(servicesInApplication).
+			Without("clean-architecture/presentation-not-depend-on-infrastructure")
+
+		results := composed.Validate(types)
+		if len(results) != ruleCount {
+			t.Fatalf("Expected %d rules, got %d", ruleCount, len(results))
+		}
+		for _, result := range results {
+			if result.RuleID == "clean-architecture/presentation-not-depend-on-infrastructure" {
+				t.Error("Expected the removed rule not to be validated")
+			}
+		}
+		if results[len(results)-1].RuleID != "conventions/services-in-application" {
+			t.Errorf("Expected the added rule last, got %s", results[len(results)-1].RuleID)
+		}
+		if len(cleanArch.Rules) != ruleCount {
+			t.Error("Expected composition to leave the original pattern untouched")
+		}
+	})
+
+	t.Run("Override changes the severity of a rule", func(t *testing.T) {
+		composed := cleanArch.Override("clean-architecture/domain-not-depend-on-application", goarchtest.SeverityWarning)
+
+		for _, result := range composed.Validate(types) {
+			expected := goarchtest.SeverityError
+			if result.RuleID == "clean-architecture/domain-not-depend-on-application" {
+				expected = goarchtest.SeverityWarning
+			}
+			if result.Severity != expected {
+				t.Errorf("Expected %s to have severity %s, got %s", result.RuleID, expected, result.Severity)
+			}
+		}
+		if cleanArch.Rules[0].Severity != "" {
+			t.Error("Expected Override to leave the original pattern untouched")
+		}
+	})
+
+	t.Run("Merge keeps the rule IDs of the other pattern", func(t *testing.T) {
+		conventions := &goarchtest.ArchitecturePattern{
+			Name: "Conventions",
+			Rules: []goarchtest.Rule{
+				{
+					Description: "Repositories should reside in infrastructure",
+					Validate: func(types *goarchtest.Types) *goarchtest.Result {
+						return types.That().HaveNameEndingWith("Repository").Should().ResideInNamespace("infrastructure").GetResult()
+					},
+				},
+			},
+		}
+
+		results := cleanArch.Merge(conventions).Validate(types)
+		last := results[len(results)-1]
+		if len(results) != ruleCount+1 || last.RuleID != "conventions/repositories-should-reside-in-infrastructure" {
+			t.Errorf("Expected the merged rule with its original ID, got %d rules ending with %s", len(results), last.RuleID)
+		}
+	})
+
+	t.Run("Rules without an ID or description are matched by their reported ID", func(t *testing.T) {
+		undescribed := func(name string) *goarchtest.ArchitecturePattern {
+			return &goarchtest.ArchitecturePattern{
+				Name: name,
+				Rules: []goarchtest.Rule{
+					{
+						Validate: func(types *goarchtest.Types) *goarchtest.Result {
+							return types.That().HaveNameEndingWith("Service").Should().ResideInNamespace("application").GetResult()
+						},
+					},
+				},
+			}
+		}
+
+		results := undescribed("Conventions").Validate(types)
+		if len(results) != 1 || !strings.HasPrefix(results[0].RuleID, "conventions/") || results[0].RuleID == "conventions/" {
+			t.Fatalf("Expected an ID generated from the rule chain, got %v", results)
+		}
+		id := results[0].RuleID
+
+		if without := undescribed("Conventions").Without(id).Validate(types); len(without) != 0 {
+			t.Errorf("Expected Without(%q) to remove the rule, got %d results", id, len(without))
+		}
+		overridden := undescribed("Conventions").Override(id, goarchtest.SeverityWarning).Validate(types)
+		if len(overridden) != 1 || overridden[0].Severity != goarchtest.SeverityWarning {
+			t.Errorf("Expected Override(%q) to change the severity, got %v", id, overridden)
+		}
+
+		merged := undescribed("Conventions").Merge(undescribed("Naming")).Validate(types)
+		if len(merged) != 2 || merged[0].RuleID != id || merged[1].RuleID != "naming/"+strings.TrimPrefix(id, "conventions/") {
+			t.Fatalf("Expected merged rules to keep distinct IDs, got %v", merged)
+		}
+		if remaining := undescribed("Conventions").Merge(undescribed("Naming")).Without(merged[1].RuleID).Validate(types); len(remaining) != 1 || remaining[0].RuleID != id {
+			t.Errorf("Expected Without(%q) to remove only the merged rule, got %v", merged[1].RuleID, remaining)
+		}
+
+		// Exclusions stay with the rules of the pattern they were made on, even
+		// when the rules of both patterns share the same generated ID
+		excluded := undescribed("Conventions").Without(id)
+		if kept := excluded.Merge(undescribed("Conventions")).Validate(types); len(kept) != 1 || kept[0].RuleID != id {
+			t.Errorf("Expected Without on the receiver to leave the merged rule, got %v", kept)
+		}
+		if kept := undescribed("Conventions").Merge(excluded).Validate(types); len(kept) != 1 || kept[0].RuleID != id {
+			t.Errorf("Expected Without on the merged pattern to leave the receiver's rule, got %v", kept)
+		}
+		warned := undescribed("Conventions").Override(id, goarchtest.SeverityWarning).Merge(undescribed("Conventions")).Validate(types)
+		if len(warned) != 2 || warned[0].Severity != goarchtest.SeverityWarning || warned[1].Severity != goarchtest.SeverityError {
+			t.Errorf("Expected Override on the receiver to leave the merged rule, got %v", warned)
+		}
+	})
+
+	t.Run("Nested patterns group their results", func(t *testing.T) {
+		conventions := &goarchtest.ArchitecturePattern{Name: "Conventions", Rules: []goarchtest.Rule{servicesInApplication}}
+		service := (&goarchtest.ArchitecturePattern{Name: "User Service"}).Nest(cleanArch, conventions)
+
+		results := service.Validate(types)
+		groups := goarchtest.GroupByPattern(results)
+		if len(groups["User Service > Clean Architecture"]) != ruleCount || len(groups["User Service > Conventions"]) != 1 {
+			t.Errorf("Expected results grouped by nested pattern, got %v", groups)
+		}
+
+		if without := service.Without("conventions/services-in-application").Validate(types); len(without) != ruleCount {
+			t.Errorf("Expected Without to remove rules of nested patterns, got %d results", len(without))
+		}
+
+		var output strings.Builder
+		goarchtest.NewErrorReporter(&output).ReportPatternValidation(results)
+		if !strings.Contains(output.String(), "Validating User Service Pattern") ||
+			!strings.Contains(output.String(), "User Service > Conventions") {
+			t.Errorf("Expected the report to show the nested patterns, got:\n%s", output.String())
+		}
+	})
+}
+
+// TestParallelValidation tests that concurrent rule evaluation is deterministic
+func TestParallelValidation(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	pattern := goarchtest.DDDArchitecture([]string{"domain", "application", "infrastructure", "presentation"},
+		goarchtest.WithDDDRoot(""),
+		goarchtest.WithDDDLayers("entities", "services", "handlers"),
+	).Merge(goarchtest.CleanArchitecture("domain", "application", "infrastructure", "presentation"))
+
+	types := goarchtest.InPath(projectPath)
+	pattern.Parallelism = 1
+	sequential := pattern.Validate(types)
+	pattern.Parallelism = 8
+	parallel := pattern.Validate(types)
+	if pattern.Without().Parallelism != 8 {
+		t.Error("Expected composition to keep the parallelism of the pattern")
+	}
+
+	if len(parallel) != len(sequential) {
+		t.Fatalf("Expected %d results, got %d", len(sequential), len(parallel))
+	}
+
+	var total time.Duration
+	for i := range sequential {
+		if parallel[i].RuleID != sequential[i].RuleID || parallel[i].IsSuccessful != sequential[i].IsSuccessful {
+			t.Errorf("Result %d differs: %s (%v) vs %s (%v)", i,
+				parallel[i].RuleID, parallel[i].IsSuccessful, sequential[i].RuleID, sequential[i].IsSuccessful)
+		}
+		total += parallel[i].Duration
+	}
+	if total <= 0 {
+		t.Error("Expected rules to report how long they took")
+	}
+}
+
+// TestSharedModel tests that parallel tests share a single model per path and options
+func TestSharedModel(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	models := make([]*goarchtest.Types, 8)
+	t.Run("group", func(t *testing.T) {
+		for i := range models {
+			t.Run(fmt.Sprintf("test%d", i), func(t *testing.T) {
+				t.Parallel()
+				models[i] = goarchtest.Shared(projectPath)
+
+				result := models[i].That().
+					ResideInNamespace("domain").
+					ShouldNot().
+					HaveDependencyOn("infrastructure").
+					GetResult()
+				archtesting.AssertRule(t, result)
+			})
+		}
+	})
+
+	for i, model := range models {
+		if model != models[0] {
+			t.Errorf("Expected test %d to share the model", i)
+		}
+	}
+
+	if goarchtest.Shared("./") != models[0] {
+		t.Error("Expected relative and absolute paths to share the model")
+	}
+	if goarchtest.Shared(projectPath, goarchtest.WithStrictMode()) == models[0] {
+		t.Error("Expected different options to load another model")
+	}
+	if goarchtest.Shared(projectPath, goarchtest.WithLoadTimeout(time.Hour)) != models[0] {
+		t.Error("Expected the load timeout not to split the shared model")
+	}
+
+	// Progress callbacks from the same function literal must each observe a load
+	calls := make([]int, 2)
+	for i := range calls {
+		goarchtest.Shared(projectPath, goarchtest.WithProgress(func(goarchtest.Progress) { calls[i]++ }))
+	}
+	if calls[0] == 0 || calls[1] == 0 {
+		t.Errorf("Expected every progress callback to be invoked, got %v calls", calls)
+	}
+
+	reloaded := models[0].Reload()
+	if reloaded == models[0] {
+		t.Fatal("Expected Reload to load a new model")
+	}
+	if goarchtest.Shared(projectPath) != reloaded {
+		t.Error("Expected Shared to return the reloaded model")
+	}
+	if got, expected := len(reloaded.That().GetAllTypes()), len(models[0].That().GetAllTypes()); got != expected {
+		t.Errorf("Expected the reloaded model to have %d types, got %d", expected, got)
+	}
+}
+
+// TestJSONReport tests that the JSON report carries rule metadata and positions, and round-trips
+func TestJSONReport(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	pattern := &goarchtest.ArchitecturePattern{
+		Name: "Layering",
+		Rules: []goarchtest.Rule{
+			{
+				ID:          "layering/infrastructure-not-depend-on-domain",
+				Description: "Infrastructure should not depend on the domain",
+				Severity:    goarchtest.SeverityWarning,
+				Validate: func(types *goarchtest.Types) *goarchtest.Result {
+					return types.That().
+						ResideInNamespace("infrastructure").
+						ShouldNot().
+						HaveDependencyOn("domain").
+						GetResult()
+				},
+			},
+		},
+	}
+
+	reporter := goarchtest.NewReporter()
+	reporter.AddResult(types.That().ResideInNamespace("domain").ShouldNot().HaveDependencyOn("presentation").GetResult())
+	reporter.AddValidationResults(pattern.Validate(types))
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := reporter.SaveReport("json", path); err != nil {
+		t.Fatalf("Failed to save report: %v", err)
+	}
+	report, err := goarchtest.LoadReport(path)
+	if err != nil {
+		t.Fatalf("Failed to load report: %v", err)
+	}
+
+	if report.Version != goarchtest.ReportVersion || report.Metadata.Tool != "goarchtest" || report.Metadata.GeneratedAt.IsZero() {
+		t.Errorf("Unexpected report metadata: version %d, %+v", report.Version, report.Metadata)
+	}
+	if report.Summary != (goarchtest.ReportSummary{Total: 2, Passed: 1, Failed: 1}) {
+		t.Errorf("Unexpected summary: %+v", report.Summary)
+	}
+	if len(report.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(report.Rules))
+	}
+
+	rule := report.Rules[1]
+	if rule.ID != "layering/infrastructure-not-depend-on-domain" || rule.Pattern != "Layering" ||
+		rule.Severity != goarchtest.SeverityWarning || rule.Passed || rule.SelectedCount != 1 {
+		t.Errorf("Unexpected rule: %+v", rule)
+	}
+	if len(rule.Violations) != 1 {
+		t.Fatalf("Expected 1 violation, got %+v", rule.Violations)
+	}
+
+	violation := rule.Violations[0]
+	expected := goarchtest.Position{File: "infrastructure/user_repository.go", Line: 7, Column: 2}
+	if violation.Type != "InMemoryUserRepository" || violation.Evidence != "github.com/solrac97gr/goarchtest/test/clean_architecture/domain" ||
+		violation.Position == nil || *violation.Position != expected {
+		t.Errorf("Unexpected violation: %+v at %v", violation, violation.Position)
+	}
+
+	// The documented struct round-trips through JSON
+	built := reporter.Report()
+	if err := built.Save(path); err != nil {
+		t.Fatalf("Failed to save report: %v", err)
+	}
+	loaded, err := goarchtest.LoadReport(path)
+	if err != nil {
+		t.Fatalf("Failed to load report: %v", err)
+	}
+	if !reflect.DeepEqual(built, loaded) {
+		t.Errorf("Expected the report to round-trip:\n%+v\n%+v", built, loaded)
+	}
+}
+
+// TestJUnitReport tests that the JUnit report has a testsuite per pattern and a testcase per rule
+func TestJUnitReport(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	infrastructureRule := func(severity goarchtest.Severity) goarchtest.Rule {
+		return goarchtest.Rule{
+			ID:          "layering/infrastructure-not-depend-on-domain",
+			Description: "Infrastructure should not depend on the domain",
+			Severity:    severity,
+			Validate: func(types *goarchtest.Types) *goarchtest.Result {
+				return types.That().
+					ResideInNamespace("infrastructure").
+					ShouldNot().
+					HaveDependencyOn("domain").
+					GetResult()
+			},
+		}
+	}
+	strict := &goarchtest.ArchitecturePattern{
+		Name:  "Strict Layering",
+		Rules: []goarchtest.Rule{infrastructureRule(goarchtest.SeverityError)},
+	}
+	lenient := &goarchtest.ArchitecturePattern{
+		Name:  "Lenient Layering",
+		Rules: []goarchtest.Rule{infrastructureRule(goarchtest.SeverityWarning)},
+	}
+
+	reporter := goarchtest.NewReporter()
+	reporter.AddResult(types.That().ResideInNamespace("domain").ShouldNot().HaveDependencyOn("presentation").GetResult())
+	reporter.AddValidationResults(strict.Validate(types))
+	reporter.AddValidationResults(lenient.Validate(types))
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := reporter.SaveReport("junit", path); err != nil {
+		t.Fatalf("Failed to save report: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+
+	var suites struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name     string `xml:"name,attr"`
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Cases    []struct {
+				Name      string `xml:"name,attr"`
+				Classname string `xml:"classname,attr"`
+				Failure   *struct {
+					Message string `xml:"message,attr"`
+					Text    string `xml:",chardata"`
+				} `xml:"failure"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("Invalid JUnit XML: %v\n%s", err, data)
+	}
+
+	if suites.Tests != 3 || suites.Failures != 1 || len(suites.Suites) != 3 {
+		t.Fatalf("Expected 3 suites with 3 tests and 1 failure, got %+v", suites)
+	}
+	for i, name := range []string{"Architecture Rules", "Strict Layering", "Lenient Layering"} {
+		if suites.Suites[i].Name != name || suites.Suites[i].Tests != 1 || len(suites.Suites[i].Cases) != 1 {
+			t.Errorf("Unexpected suite %d: %+v", i, suites.Suites[i])
+		}
+	}
+	if suites.Suites[0].Cases[0].Failure != nil {
+		t.Errorf("Expected the ad-hoc result to pass, got %+v", suites.Suites[0].Cases[0].Failure)
+	}
+
+	// Only the rule with error severity fails, listing its violation with its position
+	failed := suites.Suites[1].Cases[0]
+	if failed.Name != "layering/infrastructure-not-depend-on-domain" || failed.Classname != "Strict Layering" || failed.Failure == nil {
+		t.Fatalf("Expected the strict rule to fail, got %+v", failed)
+	}
+	if !strings.Contains(failed.Failure.Text, "infrastructure/user_repository.go:7:2: InMemoryUserRepository in package infrastructure") {
+		t.Errorf("Expected the failure to list the violation with its position, got %q", failed.Failure.Text)
+	}
+
+	warned := suites.Suites[2].Cases[0]
+	if warned.Failure != nil || !strings.Contains(warned.SystemOut, "infrastructure/user_repository.go:7:2") {
+		t.Errorf("Expected the warning rule to pass with its violation in the output, got %+v", warned)
+	}
+}
+
+// TestSARIFReport tests that the SARIF report is valid against the SARIF 2.1.0 schema
+// and maps rules and violations to SARIF rules and located results
+func TestSARIFReport(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	pattern := &goarchtest.ArchitecturePattern{
+		Name: "Layering",
+		Rules: []goarchtest.Rule{
+			{
+				ID:          "layering/infrastructure-not-depend-on-domain",
+				Description: "Infrastructure should not depend on the domain",
+				Tags:        []string{"layering"},
+				Rationale:   "Adapters are replaceable only if the domain does not know about them",
+				DocLinks:    []string{"https://example.com/adr/0001"},
+				Validate: func(types *goarchtest.Types) *goarchtest.Result {
+					return types.That().
+						ResideInNamespace("infrastructure").
+						ShouldNot().
+						HaveDependencyOn("domain").
+						GetResult()
+				},
+			},
+			{
+				ID:          "layering/domain-free-of-presentation",
+				Description: "Domain should not depend on presentation",
+				Severity:    goarchtest.SeverityInfo,
+				Validate: func(types *goarchtest.Types) *goarchtest.Result {
+					return types.That().ResideInNamespace("domain").ShouldNot().HaveDependencyOn("presentation").GetResult()
+				},
+			},
+			{
+				ID:          "layering/invalid",
+				Description: "Exclusions require a reason",
+				Validate: func(types *goarchtest.Types) *goarchtest.Result {
+					return types.That().ResideInNamespace("infrastructure").ExceptTypes("", "InMemoryUserRepository").ShouldNot().HaveDependencyOn("domain").GetResult()
+				},
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.sarif")
+	if err := goarchtest.SaveSARIF(path, pattern.Validate(types)); err != nil {
+		t.Fatalf("Failed to save report: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+
+	schemaFile, err := os.Open(filepath.Join("testdata", "sarif-schema-2.1.0.json"))
+	if err != nil {
+		t.Fatalf("Failed to open the SARIF schema: %v", err)
+	}
+	defer schemaFile.Close()
+	schemaDoc, err := jsonschema.UnmarshalJSON(schemaFile)
+	if err != nil {
+		t.Fatalf("Invalid SARIF schema: %v", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	if err := compiler.AddResource("sarif-schema-2.1.0.json", schemaDoc); err != nil {
+		t.Fatalf("Failed to add the SARIF schema: %v", err)
+	}
+	schema, err := compiler.Compile("sarif-schema-2.1.0.json")
+	if err != nil {
+		t.Fatalf("Failed to compile the SARIF schema: %v", err)
+	}
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if err := schema.Validate(instance); err != nil {
+		t.Fatalf("Expected the report to be valid SARIF 2.1.0: %v\n%s", err, data)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID                   string `json:"id"`
+						HelpURI              string `json:"helpUri"`
+						DefaultConfiguration struct {
+							Level string `json:"level"`
+						} `json:"defaultConfiguration"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Invocations []struct {
+				ExecutionSuccessful            bool `json:"executionSuccessful"`
+				ToolConfigurationNotifications []struct {
+					AssociatedRule struct {
+						ID string `json:"id"`
+					} `json:"associatedRule"`
+				} `json:"toolConfigurationNotifications"`
+			} `json:"invocations"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Failed to decode report: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Expected a single SARIF 2.1.0 run, got %+v", log)
+	}
+	run := log.Runs[0]
+
+	rules := run.Tool.Driver.Rules
+	if len(rules) != 3 || rules[0].ID != "layering/infrastructure-not-depend-on-domain" ||
+		rules[0].DefaultConfiguration.Level != "error" || rules[0].HelpURI != "https://example.com/adr/0001" ||
+		rules[1].DefaultConfiguration.Level != "note" {
+		t.Errorf("Unexpected rules: %+v", rules)
+	}
+
+	if len(run.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %+v", run.Results)
+	}
+	result := run.Results[0]
+	if result.RuleID != rules[0].ID || result.RuleIndex != 0 || result.Level != "error" || len(result.Locations) != 1 {
+		t.Fatalf("Unexpected result: %+v", result)
+	}
+	location := result.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "infrastructure/user_repository.go" || location.Region.StartLine != 7 {
+		t.Errorf("Expected the result at infrastructure/user_repository.go:7, got %+v", location)
+	}
+
+	invocation := run.Invocations[0]
+	if invocation.ExecutionSuccessful || len(invocation.ToolConfigurationNotifications) != 1 ||
+		invocation.ToolConfigurationNotifications[0].AssociatedRule.ID != "layering/invalid" {
+		t.Errorf("Expected the invalid rule to be reported as a notification, got %+v", invocation)
+	}
+}
+
+// TestMarkdownReport tests the Markdown report, in full and truncated to a size limit
+func TestMarkdownReport(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	pattern := &goarchtest.ArchitecturePattern{
+		Name: "Layering",
+		Rules: []goarchtest.Rule{
+			{
+				ID:          "layering/infrastructure-not-depend-on-domain",
+				Description: "Infrastructure should not depend on the domain",
+				Validate: func(types *goarchtest.Types) *goarchtest.Result {
+					return types.That().
+						ResideInNamespace("infrastructure").
+						ShouldNot().
+						HaveDependencyOn("domain").
+						GetResult()
+				},
+			},
+			{
+				ID:          "layering/domain-free-of-presentation",
+				Description: "Domain should not depend on presentation",
+				Validate: func(types *goarchtest.Types) *goarchtest.Result {
+					return types.That().ResideInNamespace("domain").ShouldNot().HaveDependencyOn("presentation").GetResult()
+				},
+			},
+		},
+	}
+
+	reporter := goarchtest.NewReporter()
+	reporter.AddValidationResults(pattern.Validate(types))
+
+	t.Run("Full report", func(t *testing.T) {
+		markdown := reporter.GenerateMarkdownReport()
+		for _, expected := range []string{
+			"**1 of 2 rules failed.**",
+			"| Layering | 2 | 1 | 1 |",
+			"<summary><code>layering/infrastructure-not-depend-on-domain</code>: Infrastructure should not depend on the domain (error, 1 violation(s))</summary>",
+			"| `infrastructure.InMemoryUserRepository` | `infrastructure/user_repository.go:7:2` | github.com/solrac97gr/goarchtest/test/clean_architecture/domain |",
+		} {
+			if !strings.Contains(markdown, expected) {
+				t.Errorf("Expected the report to contain %q:\n%s", expected, markdown)
+			}
+		}
+		if strings.Contains(markdown, "domain-free-of-presentation</code>") || strings.Contains(markdown, "truncated") {
+			t.Errorf("Expected only the failing rule to be detailed:\n%s", markdown)
+		}
+
+		path := filepath.Join(t.TempDir(), "report.md")
+		if err := reporter.SaveReport("markdown", path); err != nil {
+			t.Fatalf("Failed to save report: %v", err)
+		}
+		if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "| Layering | 2 | 1 | 1 |") {
+			t.Errorf("Expected the saved report to be Markdown, got %q (%v)", data, err)
+		}
+	})
+
+	t.Run("Size-limited report", func(t *testing.T) {
+		large := goarchtest.NewReporter()
+		for i := 0; i < 5; i++ {
+			result := &goarchtest.ValidationResult{
+				PatternName:     "Generated",
+				RuleID:          fmt.Sprintf("generated/rule-%d", i),
+				RuleDescription: fmt.Sprintf("Generated rule %d", i),
+			}
+			for j := 0; j < 100; j++ {
+				result.Violations = append(result.Violations, goarchtest.Violation{
+					Type:     &goarchtest.TypeInfo{Name: fmt.Sprintf("Type%d", j), Package: "domain", FullPath: "example.com/app/domain"},
+					Evidence: "example.com/app/infrastructure",
+					Position: goarchtest.Position{File: "domain/types.go", Line: j + 1},
+				})
+			}
+			large.AddValidationResults([]*goarchtest.ValidationResult{result})
+		}
+
+		full := large.GenerateMarkdownReport()
+		for _, limit := range []int{500, 8000, 20000} {
+			markdown := large.GenerateMarkdownReportWithLimit(limit)
+			if len(markdown) > limit {
+				t.Errorf("Expected at most %d bytes, got %d", limit, len(markdown))
+			}
+			if !strings.Contains(markdown, "| Generated | 5 | 0 | 5 |") {
+				t.Errorf("Expected the summary table to be kept with a limit of %d:\n%s", limit, markdown)
+			}
+			if !strings.HasSuffix(markdown, "See the full report for details._\n") {
+				t.Errorf("Expected a truncation note with a limit of %d:\n%s", limit, markdown)
+			}
+			if strings.Count(markdown, "<details>") != strings.Count(markdown, "</details>") {
+				t.Errorf("Expected every section to be closed with a limit of %d:\n%s", limit, markdown)
+			}
+		}
+
+		markdown := large.GenerateMarkdownReportWithLimit(8000)
+		if !strings.Contains(markdown, "more violation(s) omitted_") {
+			t.Errorf("Expected the last section to be cut to the violations that fit:\n%s", markdown)
+		}
+		if large.GenerateMarkdownReportWithLimit(len(full)) != full {
+			t.Error("Expected a report within the limit not to be truncated")
+		}
+	})
+}
+
+// TestFormatters tests that reports are written through the formatter registry,
+// including custom formatters, and that every format covers validation results
+func TestFormatters(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	pattern := &goarchtest.ArchitecturePattern{
+		Name: "Layering",
+		Rules: []goarchtest.Rule{
+			{
+				ID:          "layering/infrastructure-not-depend-on-domain",
+				Description: "Infrastructure should not depend on the domain",
+				Validate: func(types *goarchtest.Types) *goarchtest.Result {
+					return types.That().
+						ResideInNamespace("infrastructure").
+						ShouldNot().
+						HaveDependencyOn("domain").
+						GetResult()
+				},
+			},
+		},
+	}
+	results := pattern.Validate(types)
+
+	reporter := goarchtest.NewReporter()
+	reporter.AddResult(types.That().ResideInNamespace("domain").ShouldNot().HaveDependencyOn("presentation").GetResult())
+	reporter.AddValidationResults(results)
+
+	t.Run("Built-in formats are registered", func(t *testing.T) {
+		names := goarchtest.Formatters()
+		for _, name := range []string{"console", "html", "json", "junit", "markdown", "sarif", "text"} {
+			if !slices.Contains(names, name) {
+				t.Errorf("Expected %q among the formatters, got %v", name, names)
+			}
+		}
+		if err := reporter.SaveReport("pdf", filepath.Join(t.TempDir(), "report.pdf")); err == nil {
+			t.Error("Expected an unregistered report type to be rejected")
+		}
+	})
+
+	t.Run("Text and HTML cover validation results", func(t *testing.T) {
+		text := reporter.GenerateTextReport()
+		for _, expected := range []string{
+			"Test #1: PASS\n",
+			"Test #2 (layering/infrastructure-not-depend-on-domain): FAIL [error]\n",
+			"Pattern: Layering\n",
+			"  - InMemoryUserRepository in package infrastructure\n",
+			"Summary: 1 passed, 1 failed\n",
+		} {
+			if !strings.Contains(text, expected) {
+				t.Errorf("Expected the text report to contain %q:\n%s", expected, text)
+			}
+		}
+
+		page := reporter.GenerateHTMLReport()
+		if !strings.Contains(page, "Test #2 (layering/infrastructure-not-depend-on-domain): FAIL [error]") ||
+			!strings.Contains(page, "<li>InMemoryUserRepository in package infrastructure") {
+			t.Errorf("Expected the HTML report to show the validation result:\n%s", page)
+		}
+	})
+
+	t.Run("Custom formatters", func(t *testing.T) {
+		goarchtest.RegisterFormatter("CSV", goarchtest.FormatterFunc(func(w io.Writer, report *goarchtest.Report) error {
+			for _, rule := range report.Rules {
+				if _, err := fmt.Fprintf(w, "%s,%t,%d\n", rule.ID, rule.Passed, len(rule.Violations)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+
+		path := filepath.Join(t.TempDir(), "report.csv")
+		if err := reporter.SaveReport("csv", path); err != nil {
+			t.Fatalf("Failed to save report: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read report: %v", err)
+		}
+		if expected := ",true,0\nlayering/infrastructure-not-depend-on-domain,false,1\n"; string(data) != expected {
+			t.Errorf("Expected %q, got %q", expected, data)
+		}
+	})
+
+	t.Run("ErrorReporter uses its formatter", func(t *testing.T) {
+		var output strings.Builder
+		errorReporter := goarchtest.NewErrorReporter(&output)
+		errorReporter.SetFormatter(goarchtest.JSONFormatter{})
+		errorReporter.ReportPatternValidation(results)
+
+		var report goarchtest.Report
+		if err := json.Unmarshal([]byte(output.String()), &report); err != nil {
+			t.Fatalf("Expected a JSON report, got %v:\n%s", err, output.String())
+		}
+		if len(report.Rules) != 1 || report.Rules[0].ID != "layering/infrastructure-not-depend-on-domain" || report.Rules[0].Passed {
+			t.Errorf("Unexpected report: %+v", report)
+		}
+
+		output.Reset()
+		errorReporter.SetFormatter(nil)
+		errorReporter.ReportPatternValidation(results)
+		if !strings.Contains(output.String(), "Validating Layering Pattern") {
+			t.Errorf("Expected the console summary without a formatter, got:\n%s", output.String())
+		}
+
+		var console strings.Builder
+		summary := goarchtest.NewReporter()
+		summary.AddValidationResults(results)
+		if err := summary.WriteReport(&console, "console"); err != nil || console.String() != output.String() {
+			t.Errorf("Expected the console formatter to write the summary of the ErrorReporter, got %v:\n%s", err, console.String())
+		}
+
+		// Replacing the "console" formatter changes the default output of every ErrorReporter
+		defer goarchtest.RegisterFormatter("console", goarchtest.ConsoleFormatter{})
+		goarchtest.RegisterFormatter("console", goarchtest.FormatterFunc(func(w io.Writer, report *goarchtest.Report) error {
+			_, err := fmt.Fprintf(w, "%d/%d rules failed\n", report.Summary.Failed, report.Summary.Total)
+			return err
+		}))
+		output.Reset()
+		goarchtest.NewErrorReporter(&output).ReportPatternValidation(results)
+		if output.String() != "1/1 rules failed\n" {
+			t.Errorf("Expected the registered console formatter to be used, got:\n%s", output.String())
+		}
+	})
+}
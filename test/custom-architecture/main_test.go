@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/solrac97gr/goarchtest"
 )
@@ -253,3 +257,374 @@ func TestSpecificValidations(t *testing.T) {
 		}
 	})
 }
+
+// TestAccessRules demonstrates reverse dependency rules
+func TestAccessRules(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+
+	t.Run("Ports should only be accessed by adapters", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("core/ports").
+			Should().
+			OnlyBeAccessedBy("adapters").
+			GetResult()
+
+		if !result.IsSuccessful {
+			t.Errorf("Ports should only be accessed by adapters: %v", result.Violations)
+		}
+	})
+
+	t.Run("Domain accessed outside the core is reported per importer", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("core/domain").
+			Should().
+			OnlyBeAccessedBy("core").
+			GetResult()
+
+		if result.IsSuccessful {
+			t.Fatal("Expected domain to be accessed by adapters")
+		}
+
+		importers := make(map[string]bool)
+		for _, violation := range result.Violations {
+			importers[violation.Evidence] = true
+		}
+
+		for _, adapter := range []string{"adapters/primary/http", "adapters/secondary/database", "adapters/secondary/messaging"} {
+			if !importers["github.com/solrac97gr/goarchtest/test/custom_architecture/"+adapter] {
+				t.Errorf("Expected %s to be reported as an unauthorized importer, got %v", adapter, importers)
+			}
+		}
+	})
+
+	t.Run("Secondary adapters should not be accessed by primary adapters", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("adapters/secondary").
+			ShouldNot().
+			BeAccessedBy("adapters/primary").
+			GetResult()
+
+		if !result.IsSuccessful {
+			t.Errorf("Secondary adapters should not be accessed by primary adapters: %v", result.Violations)
+		}
+	})
+
+	t.Run("Ports accessed by primary adapters are reported", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("core/ports").
+			ShouldNot().
+			BeAccessedBy("adapters/primary").
+			GetResult()
+
+		if result.IsSuccessful {
+			t.Fatal("Expected ports to be accessed by primary adapters")
+		}
+
+		for _, violation := range result.Violations {
+			if violation.Evidence != "github.com/solrac97gr/goarchtest/test/custom_architecture/adapters/primary/http" {
+				t.Errorf("Unexpected importer reported: %s", violation.Evidence)
+			}
+		}
+	})
+}
+
+func TestExclusions(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+
+	t.Run("Excluded types are removed from the selection", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("adapters/secondary").
+			ExceptTypes("test double, not a production adapter", "messaging.MockPaymentService").
+			Should().
+			HaveNameEndingWith("Repository").
+			GetResult()
+
+		for _, failingType := range result.FailingTypes {
+			if failingType.Name == "MockPaymentService" {
+				t.Error("Expected MockPaymentService to be excluded from the selection")
+			}
+		}
+
+		if len(result.Exemptions) != 1 || result.Exemptions[0].Type.Name != "MockPaymentService" {
+			t.Fatalf("Expected MockPaymentService to be exempted, got %v", result.Exemptions)
+		}
+		if result.Exemptions[0].Reason != "test double, not a production adapter" {
+			t.Errorf("Expected the reason to be echoed, got %q", result.Exemptions[0].Reason)
+		}
+	})
+
+	t.Run("Excluded types are exempted from the condition", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("adapters").
+			ShouldNot().
+			HaveDependencyOn("core/domain").
+			ExceptNamespaces("primary adapters map requests to domain entities", "adapters/primary").
+			GetResult()
+
+		if result.IsSuccessful {
+			t.Fatal("Expected secondary adapters to still be reported")
+		}
+
+		for _, failingType := range result.FailingTypes {
+			if failingType.Package == "http" {
+				t.Errorf("Expected %s to be exempted, but it was reported as failing", failingType.Name)
+			}
+		}
+
+		if len(result.Exemptions) == 0 {
+			t.Fatal("Expected the primary adapters to be listed as exemptions")
+		}
+		for _, exemption := range result.Exemptions {
+			if exemption.Type.Package != "http" || exemption.Reason != "primary adapters map requests to domain entities" {
+				t.Errorf("Unexpected exemption: %s in package %s (%s)", exemption.Type.Name, exemption.Type.Package, exemption.Reason)
+			}
+		}
+
+		if result.EvaluatedCount != result.SelectedCount-len(result.Exemptions) {
+			t.Errorf("Expected exempted types not to be evaluated, got %d selected and %d evaluated",
+				result.SelectedCount, result.EvaluatedCount)
+		}
+	})
+
+	t.Run("Rule passes when every violation is exempted", func(t *testing.T) {
+		adapters := types.That().ResideInNamespace("adapters")
+
+		result := types.That().
+			ResideInNamespace("adapters").
+			ShouldNot().
+			HaveDependencyOn("core/domain").
+			Except("adapters translate between the domain and the outside world", adapters).
+			GetResult()
+
+		if !result.IsSuccessful {
+			t.Errorf("Expected every violation to be exempted: %s", result.GetFailureDetails())
+		}
+	})
+
+	t.Run("Exclusions require a reason", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("adapters").
+			ExceptTypes("", "http.OrderHandler").
+			ShouldNot().
+			HaveDependencyOn("core/domain").
+			GetResult()
+
+		if result.IsSuccessful || result.Err == nil {
+			t.Error("Expected an exclusion without a reason to make the rule invalid")
+		}
+	})
+}
+
+func TestViolationBaseline(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	pattern := &goarchtest.ArchitecturePattern{
+		Name: "Legacy Adapters",
+		Rules: []goarchtest.Rule{
+			{
+				ID:          "legacy/adapters-not-depend-on-domain",
+				Description: "Adapters should not depend on the domain",
+				Validate: func(types *goarchtest.Types) *goarchtest.Result {
+					return types.That().
+						ResideInNamespace("adapters").
+						ShouldNot().
+						HaveDependencyOn("core/domain").
+						GetResult()
+				},
+			},
+		},
+	}
+	baselinePath := filepath.Join(t.TempDir(), "archtest-baseline.json")
+
+	// The first run records every current violation
+	results := pattern.Validate(types)
+	violationCount := len(results[0].Violations)
+	if violationCount == 0 {
+		t.Fatal("Expected the adapters to violate the rule")
+	}
+	if err := goarchtest.FreezeViolations(baselinePath, results); err != nil {
+		t.Fatalf("Failed to record baseline: %v", err)
+	}
+	if !results[0].IsSuccessful || results[0].FrozenCount != violationCount {
+		t.Fatalf("Expected all %d violations to be frozen, got %d (successful: %v)",
+			violationCount, results[0].FrozenCount, results[0].IsSuccessful)
+	}
+
+	baseline, err := goarchtest.LoadBaseline(baselinePath)
+	if err != nil {
+		t.Fatalf("Failed to load baseline: %v", err)
+	}
+	if len(baseline.Violations) != violationCount {
+		t.Fatalf("Expected %d baseline entries, got %d", violationCount, len(baseline.Violations))
+	}
+
+	t.Run("Later runs pass on frozen violations", func(t *testing.T) {
+		results := pattern.Validate(types)
+		if err := goarchtest.FreezeViolations(baselinePath, results); err != nil {
+			t.Fatalf("Failed to apply baseline: %v", err)
+		}
+		if !results[0].IsSuccessful || results[0].FrozenCount != violationCount {
+			t.Errorf("Expected frozen violations to pass, got %d frozen: %v", results[0].FrozenCount, results[0].Violations)
+		}
+	})
+
+	t.Run("Fixed violations shrink the baseline", func(t *testing.T) {
+		stale := goarchtest.BaselineEntry{
+			RuleID:   "legacy/adapters-not-depend-on-domain",
+			Type:     "github.com/solrac97gr/goarchtest/test/custom_architecture/adapters/removed.Handler",
+			Evidence: "github.com/solrac97gr/goarchtest/test/custom_architecture/core/domain",
+		}
+		unrelated := goarchtest.BaselineEntry{RuleID: "other/rule", Type: "example.com/pkg.Type"}
+		withStale := &goarchtest.Baseline{Violations: append(append(baseline.Violations, stale), unrelated)}
+		if err := withStale.Save(baselinePath); err != nil {
+			t.Fatalf("Failed to save baseline: %v", err)
+		}
+
+		results := pattern.Validate(types)
+		if err := goarchtest.FreezeViolations(baselinePath, results); err != nil {
+			t.Fatalf("Failed to apply baseline: %v", err)
+		}
+
+		shrunk, err := goarchtest.LoadBaseline(baselinePath)
+		if err != nil {
+			t.Fatalf("Failed to load baseline: %v", err)
+		}
+		if len(shrunk.Violations) != violationCount+1 {
+			t.Errorf("Expected the fixed violation to be removed and the unrelated rule kept, got %v", shrunk.Violations)
+		}
+		for _, entry := range shrunk.Violations {
+			if entry == stale {
+				t.Error("Expected the fixed violation to be removed from the baseline")
+			}
+		}
+	})
+
+	t.Run("New violations fail the rule", func(t *testing.T) {
+		partial := &goarchtest.Baseline{Violations: baseline.Violations[1:]}
+		if err := partial.Save(baselinePath); err != nil {
+			t.Fatalf("Failed to save baseline: %v", err)
+		}
+
+		results := pattern.Validate(types)
+		if err := goarchtest.FreezeViolations(baselinePath, results); err != nil {
+			t.Fatalf("Failed to apply baseline: %v", err)
+		}
+		if results[0].IsSuccessful {
+			t.Fatal("Expected the violation missing from the baseline to fail the rule")
+		}
+		if len(results[0].Violations) != 1 || results[0].FrozenCount != violationCount-1 {
+			t.Errorf("Expected 1 new and %d frozen violations, got %d new and %d frozen",
+				violationCount-1, len(results[0].Violations), results[0].FrozenCount)
+		}
+	})
+
+	t.Run("Failing types without violations are frozen", func(t *testing.T) {
+		adapters := types.That().ResideInNamespace("adapters").GetAllTypes()
+		if len(adapters) == 0 {
+			t.Fatal("Expected types in the adapters namespace")
+		}
+		handBuilt := &goarchtest.ArchitecturePattern{
+			Name: "Hand Built",
+			Rules: []goarchtest.Rule{
+				{
+					ID:          "legacy/hand-built",
+					Description: "Adapters should not exist",
+					Validate: func(types *goarchtest.Types) *goarchtest.Result {
+						return &goarchtest.Result{
+							FailingTypes: adapters,
+							Violations:   []goarchtest.Violation{{Reason: "no type recorded"}},
+						}
+					},
+				},
+			},
+		}
+		path := filepath.Join(t.TempDir(), "archtest-baseline.json")
+
+		for run := 1; run <= 2; run++ {
+			results := handBuilt.Validate(types)
+			if err := goarchtest.FreezeViolations(path, results); err != nil {
+				t.Fatalf("Run %d: failed to apply baseline: %v", run, err)
+			}
+			if !results[0].IsSuccessful || results[0].FrozenCount != len(adapters)+1 {
+				t.Errorf("Run %d: expected the %d failing types and the violation without a type to be frozen, got %d frozen: %v",
+					run, len(adapters), results[0].FrozenCount, results[0].Violations)
+			}
+		}
+	})
+}
+
+func TestLoadContext(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	t.Run("Loads like InPath and reports progress", func(t *testing.T) {
+		var progress []goarchtest.Progress
+		types, err := goarchtest.LoadContext(context.Background(), projectPath,
+			goarchtest.WithProgress(func(p goarchtest.Progress) {
+				progress = append(progress, p)
+			}))
+		if err != nil {
+			t.Fatalf("Failed to load packages: %v", err)
+		}
+
+		if got, expected := len(types.That().GetAllTypes()), len(goarchtest.InPath(projectPath).That().GetAllTypes()); got != expected {
+			t.Errorf("Expected %d types, got %d", expected, got)
+		}
+
+		if len(progress) < 2 {
+			t.Fatalf("Expected progress after loading and after each package, got %v", progress)
+		}
+		last := progress[len(progress)-1]
+		if progress[0].Analyzed != 0 || last.Loaded == 0 || last.Analyzed != last.Loaded {
+			t.Errorf("Expected every loaded package to be analyzed, got %v", progress)
+		}
+		for i := 1; i < len(progress); i++ {
+			if progress[i].Analyzed < progress[i-1].Analyzed {
+				t.Errorf("Expected progress to increase, got %v", progress)
+			}
+		}
+	})
+
+	t.Run("Cancelled contexts stop loading", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		types, err := goarchtest.LoadContext(ctx, projectPath)
+		if !errors.Is(err, context.Canceled) || types != nil {
+			t.Fatalf("Expected a cancellation error, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "was canceled") {
+			t.Errorf("Expected a descriptive error, got %q", err)
+		}
+	})
+
+	t.Run("Timeouts return a descriptive error", func(t *testing.T) {
+		for _, mode := range []goarchtest.LoadMode{goarchtest.LoadFull, goarchtest.LoadImports} {
+			_, err := goarchtest.LoadContext(context.Background(), projectPath,
+				goarchtest.WithLoadMode(mode),
+				goarchtest.WithLoadTimeout(time.Nanosecond))
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Expected a deadline error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "timed out after 1ns") {
+				t.Errorf("Expected the timeout in the error, got %q", err)
+			}
+		}
+	})
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -252,3 +254,522 @@ func TestNamingConventions(t *testing.T) {
 		}
 	})
 }
+
+// TestRuleDescriptions demonstrates the descriptions generated from fluent chains
+func TestRuleDescriptions(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+
+	t.Run("Chains describe themselves", func(t *testing.T) {
+		tests := []struct {
+			result   *goarchtest.Result
+			expected string
+		}{
+			{
+				result: types.That().
+					ResideInNamespace("models").
+					ShouldNot().
+					HaveDependencyOn("services").
+					GetResult(),
+				expected: "types that reside in namespace 'models' should not have dependency on 'services'",
+			},
+			{
+				result: types.That().
+					BeStruct().
+					HaveNameEndingWith("Service").
+					Should().
+					ResideInNamespace("services").
+					GetResult(),
+				expected: "types that are structs and have name ending with 'Service' should reside in namespace 'services'",
+			},
+			{
+				result: types.That().
+					AreInterfaces().
+					And().
+					WithCustomPredicate("IsExported", func(typeInfo *goarchtest.TypeInfo) bool {
+						return typeInfo.Name != strings.ToLower(typeInfo.Name)
+					}).
+					Should().
+					BeStruct().
+					GetResult(),
+				expected: "types that are interfaces and satisfy 'IsExported' should be structs",
+			},
+		}
+
+		for _, tt := range tests {
+			if tt.result.Description != tt.expected {
+				t.Errorf("Expected description %q, got %q", tt.expected, tt.result.Description)
+			}
+		}
+	})
+
+	t.Run("Rules without a description use the generated one", func(t *testing.T) {
+		pattern := &goarchtest.ArchitecturePattern{
+			Name: "Generated",
+			Rules: []goarchtest.Rule{
+				{
+					Validate: func(types *goarchtest.Types) *goarchtest.Result {
+						return types.That().ResideInNamespace("utils").ShouldNot().HaveDependencyOn("handlers").GetResult()
+					},
+				},
+			},
+		}
+
+		result := pattern.Validate(types)[0]
+		if result.RuleDescription != "types that reside in namespace 'utils' should not have dependency on 'handlers'" {
+			t.Errorf("Unexpected generated rule description: %q", result.RuleDescription)
+		}
+	})
+
+	t.Run("Reused selections describe each rule once", func(t *testing.T) {
+		models := types.That().ResideInNamespace("models")
+		models.ShouldNot().HaveDependencyOn("services").GetResult()
+
+		result := models.ShouldNot().HaveDependencyOn("handlers").GetResult()
+		if expected := "types that reside in namespace 'models' should not have dependency on 'handlers'"; result.Description != expected {
+			t.Errorf("Expected description %q, got %q", expected, result.Description)
+		}
+	})
+
+	t.Run("Chains do not affect each other", func(t *testing.T) {
+		all := len(types.That().GetAllTypes())
+		types.That().BeStruct().HaveNameEndingWith("Service")
+
+		if got := len(types.That().GetAllTypes()); got != all {
+			t.Errorf("Expected a new chain to start from all %d types, got %d", all, got)
+		}
+	})
+}
+
+func TestContextPredicates(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+
+	t.Run("Predicates see the whole model", func(t *testing.T) {
+		all := len(types.That().GetAllTypes())
+
+		result := types.That().
+			ResideInNamespace("handlers").
+			ShouldNot().
+			WithContextPredicate("seesPartialModel", func(ctx *goarchtest.PredicateContext, typeInfo *goarchtest.TypeInfo) bool {
+				return len(ctx.Types()) != all
+			}).
+			GetResult()
+
+		if !result.IsSuccessful {
+			t.Errorf("Expected every type of the model to be visible: %v", result.FailingTypes)
+		}
+	})
+
+	t.Run("Predicates see packages and type objects", func(t *testing.T) {
+		result := types.That().
+			ShouldNot().
+			WithContextPredicate("missingTypeInformation", func(ctx *goarchtest.PredicateContext, typeInfo *goarchtest.TypeInfo) bool {
+				pkg, obj := ctx.Package(), ctx.Object()
+				if pkg == nil || obj == nil {
+					ctx.Reason("no type information for %s", typeInfo.Name)
+					return true
+				}
+				if !slices.Contains(ctx.TypesIn(typeInfo.FullPath), typeInfo) {
+					ctx.Reason("%s is not indexed in its package", typeInfo.Name)
+					return true
+				}
+				return pkg.PkgPath != typeInfo.FullPath || obj.Name() != typeInfo.Name
+			}).
+			GetResult()
+
+		if !result.IsSuccessful {
+			t.Errorf("Expected package and object for every type: %v", result.Violations)
+		}
+	})
+
+	t.Run("Reasons become violations", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("handlers").
+			ShouldNot().
+			WithContextPredicate("isUnused", func(ctx *goarchtest.PredicateContext, typeInfo *goarchtest.TypeInfo) bool {
+				if len(ctx.ImportersOf(typeInfo.FullPath)) > 0 {
+					return false
+				}
+				ctx.Reason("package %s is not imported by any package", typeInfo.Package)
+				return true
+			}).
+			GetResult()
+
+		if result.IsSuccessful {
+			t.Fatal("Expected the handlers package to be reported as unused")
+		}
+		for _, violation := range result.Violations {
+			if violation.Reason != "package handlers is not imported by any package" {
+				t.Errorf("Unexpected violation reason: %q", violation.Reason)
+			}
+		}
+	})
+}
+
+func TestCustomConditions(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+
+	isServiceStruct := func(typeInfo *goarchtest.TypeInfo) (bool, string) {
+		if typeInfo.IsStruct && strings.HasSuffix(typeInfo.Name, "Service") {
+			return true, ""
+		}
+		return false, fmt.Sprintf("%s is not a service struct", typeInfo.Name)
+	}
+
+	t.Run("Satisfied conditions pass", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("services").
+			Should().
+			Satisfy("be service structs", isServiceStruct).
+			GetResult()
+
+		if !result.IsSuccessful {
+			t.Errorf("Expected services to be service structs: %s", result.GetFailureDetails())
+		}
+		if result.Description != "types that reside in namespace 'services' should satisfy 'be service structs'" {
+			t.Errorf("Unexpected description: %q", result.Description)
+		}
+	})
+
+	t.Run("Every selected type must satisfy the condition", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("services").
+			Or(types.That().ResideInNamespace("handlers")).
+			Should().
+			Satisfy("be service structs", isServiceStruct).
+			GetResult()
+
+		if result.IsSuccessful {
+			t.Fatal("Expected handlers to fail the condition")
+		}
+		if len(result.Violations) != 2 {
+			t.Fatalf("Expected one violation per handler, got %v", result.Violations)
+		}
+		for _, violation := range result.Violations {
+			if violation.Reason != violation.Type.Name+" is not a service struct" {
+				t.Errorf("Unexpected violation reason: %q", violation.Reason)
+			}
+		}
+		if !strings.Contains(result.GetFailureDetails(), "UserHandler is not a service struct") {
+			t.Errorf("Expected the reason in the failure details, got:\n%s", result.GetFailureDetails())
+		}
+	})
+
+	t.Run("Multi-violation conditions report every reason", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("handlers").
+			Should().
+			SatisfyEach("follow service conventions", func(typeInfo *goarchtest.TypeInfo) []string {
+				var reasons []string
+				if !strings.HasSuffix(typeInfo.Name, "Service") {
+					reasons = append(reasons, typeInfo.Name+" is not named as a service")
+				}
+				if typeInfo.Package != "services" {
+					reasons = append(reasons, typeInfo.Name+" is not in the services package")
+				}
+				return reasons
+			}).
+			GetResult()
+
+		if result.IsSuccessful {
+			t.Fatal("Expected handlers to violate service conventions")
+		}
+		if len(result.FailingTypes) != 2 || len(result.Violations) != 4 {
+			t.Errorf("Expected 2 failing types with 2 violations each, got %d types and %v",
+				len(result.FailingTypes), result.Violations)
+		}
+	})
+
+	t.Run("Negated conditions report the types that satisfy them", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace("models").
+			ShouldNot().
+			Satisfy("be service structs", isServiceStruct).
+			GetResult()
+
+		if !result.IsSuccessful {
+			t.Errorf("Expected no service structs in models: %v", result.FailingTypes)
+		}
+	})
+}
+
+func TestPredicateRegistry(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+
+	names := func(typeInfos []*goarchtest.TypeInfo) []string {
+		var result []string
+		for _, typeInfo := range typeInfos {
+			result = append(result, typeInfo.Name)
+		}
+		slices.Sort(result)
+		return result
+	}
+
+	t.Run("Built-in conventions select by name", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			expected []string
+		}{
+			{"handler", []string{"ProductController", "UserHandler"}},
+			{"service", []string{"EmailService", "ProductService", "UserService"}},
+			{"entity", []string{"Order", "Product", "User"}},
+			{"valueObject", []string{"Settings"}},
+		}
+
+		for _, tt := range tests {
+			got := names(types.That().Are(tt.name).GetAllTypes())
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Expected %q to select %v, got %v", tt.name, tt.expected, got)
+			}
+		}
+	})
+
+	t.Run("Named conditions report reasons", func(t *testing.T) {
+		result := types.That().
+			Are("handler").
+			Should().
+			Be("immutable").
+			GetResult()
+
+		if result.IsSuccessful {
+			t.Fatal("Expected handlers with pointer receivers not to be immutable")
+		}
+		if result.Description != "types that are 'handler' should be 'immutable'" {
+			t.Errorf("Unexpected description: %q", result.Description)
+		}
+		if !strings.Contains(result.GetFailureDetails(), "UserHandler has pointer-receiver methods: GetUser, CreateUser") {
+			t.Errorf("Expected the pointer-receiver methods in the details, got:\n%s", result.GetFailureDetails())
+		}
+	})
+
+	t.Run("Registered predicates are usable by name", func(t *testing.T) {
+		goarchtest.RegisterPredicate("isHelper", func(typeInfo *goarchtest.TypeInfo) bool {
+			return strings.HasSuffix(typeInfo.Name, "Helper")
+		})
+
+		result := types.That().Are("isHelper").Should().ResideInNamespace("utils").GetResult()
+		if !result.IsSuccessful || result.SelectedCount != 1 {
+			t.Errorf("Expected StringHelper to be selected and to reside in utils: %s", result.GetFailureDetails())
+		}
+	})
+
+	t.Run("Scoped registries do not leak", func(t *testing.T) {
+		registry := goarchtest.NewRegistry()
+		registry.RegisterCondition("namedAsModel", func(typeInfo *goarchtest.TypeInfo) (bool, string) {
+			if typeInfo.Package == "models" {
+				return true, ""
+			}
+			return false, typeInfo.Name + " is not a model"
+		})
+		scoped := goarchtest.InPath(projectPath, goarchtest.WithRegistry(registry))
+
+		result := scoped.That().Are("entity").Should().Be("namedAsModel").GetResult()
+		if !result.IsSuccessful {
+			t.Errorf("Expected entities to be models: %s", result.GetFailureDetails())
+		}
+
+		result = types.That().Are("entity").Should().Be("namedAsModel").GetResult()
+		if result.Err == nil {
+			t.Error("Expected the scoped condition to be unknown to the global registry")
+		}
+		if !slices.Contains(registry.Conditions(), "exported") {
+			t.Errorf("Expected the scoped registry to fall back to the built-in catalog, got %v", registry.Conditions())
+		}
+	})
+
+	t.Run("Unknown names make the rule invalid", func(t *testing.T) {
+		result := types.That().Are("doesNotExist").ShouldNot().ResideInNamespace("models").GetResult()
+		if result.IsSuccessful || result.Err == nil {
+			t.Error("Expected an unknown predicate to make the rule invalid")
+		}
+	})
+}
+
+func TestLoadModes(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	full := goarchtest.InPath(projectPath)
+	importsOnly := goarchtest.InPath(projectPath, goarchtest.WithLoadMode(goarchtest.LoadImports))
+
+	t.Run("Imports-only models have the same types", func(t *testing.T) {
+		describe := func(types *goarchtest.Types) []string {
+			var descriptions []string
+			for _, typeInfo := range types.That().GetAllTypes() {
+				descriptions = append(descriptions, fmt.Sprintf("%s.%s %v %t %t %v at %s",
+					typeInfo.FullPath, typeInfo.Name, typeInfo.Imports, typeInfo.IsStruct, typeInfo.IsInterface, typeInfo.Interfaces, typeInfo.Position))
+				for _, imp := range typeInfo.Imports {
+					descriptions = append(descriptions, fmt.Sprintf("imports %s at %s", imp, typeInfo.ImportPosition(imp)))
+				}
+			}
+			return descriptions
+		}
+
+		if got, expected := describe(importsOnly), describe(full); !slices.Equal(got, expected) {
+			t.Errorf("Expected the same types in both modes:\ngot:  %v\nwant: %v", got, expected)
+		}
+	})
+
+	t.Run("Dependency rules work without type information", func(t *testing.T) {
+		for _, types := range []*goarchtest.Types{full, importsOnly} {
+			result := types.That().
+				ResideInNamespace("handlers").
+				ShouldNot().
+				WithContextPredicate("isUnused", func(ctx *goarchtest.PredicateContext, typeInfo *goarchtest.TypeInfo) bool {
+					return len(ctx.ImportersOf(typeInfo.FullPath)) == 0
+				}).
+				GetResult()
+			if result.Err != nil || result.IsSuccessful {
+				t.Errorf("Expected the handlers package to be reported as unused, got %v", result.Err)
+			}
+
+			result = types.That().Are("service").Should().HaveNameEndingWith("Service").GetResult()
+			if result.Err != nil || !result.IsSuccessful {
+				t.Errorf("Expected name-based predicates to work: %v", result.Err)
+			}
+		}
+	})
+
+	t.Run("Rules needing type information report an error", func(t *testing.T) {
+		result := importsOnly.That().Are("entity").Should().ResideInNamespace("models").GetResult()
+		if result.IsSuccessful || !errors.Is(result.Err, goarchtest.ErrTypeInfoUnavailable) {
+			t.Fatalf("Expected ErrTypeInfoUnavailable, got %v", result.Err)
+		}
+		if !strings.Contains(result.Err.Error(), `"entity"`) {
+			t.Errorf("Expected the error to name the predicate, got %q", result.Err)
+		}
+
+		result = importsOnly.That().
+			ShouldNot().
+			WithContextPredicate("hasObject", func(ctx *goarchtest.PredicateContext, typeInfo *goarchtest.TypeInfo) bool {
+				return ctx.Object() != nil
+			}).
+			GetResult()
+		if !errors.Is(result.Err, goarchtest.ErrTypeInfoUnavailable) {
+			t.Errorf("Expected ErrTypeInfoUnavailable from a context predicate, got %v", result.Err)
+		}
+
+		result = full.That().Are("entity").Should().ResideInNamespace("models").GetResult()
+		if result.Err != nil {
+			t.Errorf("Expected type information in the full mode: %v", result.Err)
+		}
+	})
+}
+
+func TestFromSources(t *testing.T) {
+	const domain = "package domain\n\ntype User struct{}\n"
+
+	tests := []struct {
+		name     string
+		sources  map[string]string
+		mode     goarchtest.LoadMode
+		expected bool
+	}{
+		{
+			name: "Independent domain",
+			sources: map[string]string{
+				"domain/user.go":                    domain,
+				"infrastructure/user_repository.go": "package infrastructure\n\nimport \"app/domain\"\n\ntype UserRepository struct{ users []domain.User }\n",
+			},
+			expected: true,
+		},
+		{
+			name: "Domain depending on infrastructure",
+			sources: map[string]string{
+				"domain/user.go":       "package domain\n\nimport \"app/infrastructure\"\n\ntype User struct{ db infrastructure.DB }\n",
+				"infrastructure/db.go": "package infrastructure\n\ntype DB struct{}\n",
+			},
+			expected: false,
+		},
+		{
+			name: "Domain depending on infrastructure, imports only",
+			sources: map[string]string{
+				"domain/user.go":       "package domain\n\nimport \"app/infrastructure\"\n\ntype User struct{ db infrastructure.DB }\n",
+				"infrastructure/db.go": "package infrastructure\n\ntype DB struct{}\n",
+			},
+			mode:     goarchtest.LoadImports,
+			expected: false,
+		},
+		{
+			name: "Custom module path",
+			sources: map[string]string{
+				"go.mod":               "module example.com/shop\n\ngo 1.24\n",
+				"domain/user.go":       "package domain\n\nimport \"example.com/shop/infrastructure\"\n\nvar _ infrastructure.DB\n\ntype User struct{}\n",
+				"infrastructure/db.go": "package infrastructure\n\ntype DB struct{}\n",
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types, err := goarchtest.FromSources(tt.sources, goarchtest.WithLoadMode(tt.mode))
+			if err != nil {
+				t.Fatalf("Failed to load sources: %v", err)
+			}
+
+			result := types.That().
+				ResideInNamespace("domain").
+				ShouldNot().
+				HaveDependencyOn("infrastructure").
+				GetResult()
+			if result.IsSuccessful != tt.expected || result.SelectedCount != 1 {
+				t.Errorf("Expected success %v with 1 selected type, got %v with %d: %s",
+					tt.expected, result.IsSuccessful, result.SelectedCount, result.GetFailureDetails())
+			}
+			for _, violation := range result.Violations {
+				if violation.Position.File != "domain/user.go" || violation.Position.Line != 3 {
+					t.Errorf("Expected the violation at the import in domain/user.go:3, got %s", violation.Position)
+				}
+			}
+		})
+	}
+
+	t.Run("Models can be reloaded", func(t *testing.T) {
+		types, err := goarchtest.FromSources(map[string]string{"domain/user.go": domain})
+		if err != nil {
+			t.Fatalf("Failed to load sources: %v", err)
+		}
+		if reloaded := types.Reload(); len(reloaded.That().GetAllTypes()) != 1 {
+			t.Errorf("Expected the reloaded model to have the User type, got %v", reloaded.That().GetAllTypes())
+		}
+	})
+
+	t.Run("Invalid sources report an error", func(t *testing.T) {
+		_, err := goarchtest.FromSources(map[string]string{"domain/user.go": "package domain\n\ntype User struct{ id UnknownID }\n"})
+		if err == nil || !strings.Contains(err.Error(), "domain/user.go:3") || strings.Contains(err.Error(), "goarchtest-sources") {
+			t.Errorf("Expected a type error at domain/user.go:3, got %v", err)
+		}
+
+		_, err = goarchtest.FromSources(map[string]string{"domain/user.go": "package domain\n\ntype User struct{\n"}, goarchtest.WithLoadMode(goarchtest.LoadImports))
+		if err == nil || !strings.Contains(err.Error(), "domain/user.go:") || strings.Contains(err.Error(), "goarchtest-sources") {
+			t.Errorf("Expected a syntax error in domain/user.go without type-checking, got %v", err)
+		}
+
+		_, err = goarchtest.FromSources(map[string]string{"../user.go": domain})
+		if err == nil {
+			t.Error("Expected paths outside the module to be rejected")
+		}
+	})
+}
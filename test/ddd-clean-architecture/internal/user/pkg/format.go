@@ -0,0 +1,15 @@
+package pkg
+
+import (
+	"fmt"
+
+	"github.com/solrac97gr/goarchtest/test/ddd_clean_architecture/internal/user/domain/models"
+)
+
+// UserFormatter formats users for display within the user bounded context
+type UserFormatter struct{}
+
+// Format returns a display name for the user
+func (UserFormatter) Format(user *models.User) string {
+	return fmt.Sprintf("%s <%s>", user.Name, user.Email)
+}
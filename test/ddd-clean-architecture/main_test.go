@@ -2,6 +2,7 @@ package main
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/solrac97gr/goarchtest"
@@ -298,3 +299,232 @@ func TestCleanArchitectureWithinBoundedContexts(t *testing.T) {
 		}
 	})
 }
+
+// TestNamespacePatterns tests the glob, regex and exact namespace matching modes
+func TestNamespacePatterns(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	modulePath := "github.com/solrac97gr/goarchtest/test/ddd_clean_architecture"
+
+	packagesOf := func(typeInfos []*goarchtest.TypeInfo) map[string]bool {
+		paths := make(map[string]bool)
+		for _, typeInfo := range typeInfos {
+			paths[typeInfo.FullPath] = true
+		}
+		return paths
+	}
+
+	t.Run("Glob with a single segment wildcard selects every bounded context", func(t *testing.T) {
+		paths := packagesOf(types.That().ResideInNamespace("internal/*/domain").GetAllTypes())
+
+		for _, expected := range []string{
+			modulePath + "/internal/user/domain/models",
+			modulePath + "/internal/order/domain/ports",
+		} {
+			if !paths[expected] {
+				t.Errorf("Expected %s to match internal/*/domain, got %v", expected, paths)
+			}
+		}
+		if paths[modulePath+"/internal/user/application"] {
+			t.Error("internal/user/application should not match internal/*/domain")
+		}
+	})
+
+	t.Run("Glob with segment wildcards in the middle", func(t *testing.T) {
+		paths := packagesOf(types.That().ResideInNamespace("internal..ports").GetAllTypes())
+
+		if len(paths) != 2 {
+			t.Errorf("Expected both ports packages to match internal..ports, got %v", paths)
+		}
+	})
+
+	t.Run("Exact match excludes sub-packages", func(t *testing.T) {
+		if got := types.That().ResideInNamespace(goarchtest.ExactNamespace(modulePath + "/internal/user/domain")).GetAllTypes(); len(got) != 0 {
+			t.Errorf("Expected no types directly in internal/user/domain, got %d", len(got))
+		}
+
+		paths := packagesOf(types.That().ResideInNamespace(goarchtest.ExactNamespace(modulePath + "/shared")).GetAllTypes())
+		if len(paths) != 1 || !paths[modulePath+"/shared"] {
+			t.Errorf("Expected only the shared package, got %v", paths)
+		}
+	})
+
+	t.Run("Regex matches the full import path", func(t *testing.T) {
+		paths := packagesOf(types.That().ResideInNamespace(goarchtest.RegexNamespace(`/internal/(user|order)/application$`)).GetAllTypes())
+
+		if len(paths) != 2 {
+			t.Errorf("Expected both application packages, got %v", paths)
+		}
+	})
+
+	t.Run("Go-style ellipsis matches a package and its sub-packages", func(t *testing.T) {
+		paths := packagesOf(types.That().ResideInNamespace("internal/user/...").GetAllTypes())
+
+		if len(paths) == 0 {
+			t.Fatal("Expected internal/user/... to match the user bounded context")
+		}
+		for path := range paths {
+			if !strings.Contains(path, "/internal/user/") {
+				t.Errorf("Expected only packages of the user bounded context, got %s", path)
+			}
+		}
+		if got, expected := paths, packagesOf(types.That().ResideInNamespace("internal/user..").GetAllTypes()); len(got) != len(expected) {
+			t.Errorf("Expected internal/user/... to match like internal/user.., got %v and %v", got, expected)
+		}
+	})
+
+	t.Run("Trailing ellipsis decides whether sub-packages match", func(t *testing.T) {
+		withSubPackages := packagesOf(types.That().ResideInNamespace("user/domain..").GetAllTypes())
+		if !withSubPackages[modulePath+"/internal/user/domain/models"] || !withSubPackages[modulePath+"/internal/user/domain/ports"] {
+			t.Errorf("Expected user/domain.. to match the sub-packages of user/domain, got %v", withSubPackages)
+		}
+
+		for _, pattern := range []string{"..domain", "internal..domain"} {
+			if got := types.That().ResideInNamespace(pattern).GetAllTypes(); len(got) != 0 {
+				t.Errorf("Expected %s to match only the domain packages, which have no types, got %v", pattern, packagesOf(got))
+			}
+		}
+		if paths := packagesOf(types.That().ResideInNamespace("..domain/models").GetAllTypes()); len(paths) != 2 {
+			t.Errorf("Expected ..domain/models to match both models packages, got %v", paths)
+		}
+	})
+
+	t.Run("Relative path segments are reported as an error", func(t *testing.T) {
+		for _, pattern := range []string{"./internal", "internal/./user", "internal/...."} {
+			result := types.That().
+				ResideInNamespace(pattern).
+				ShouldNot().
+				HaveDependencyOn("infrastructure").
+				GetResult()
+
+			if result.IsSuccessful || result.Err == nil {
+				t.Errorf("Expected %q to fail the rule with an error instead of matching nothing", pattern)
+			}
+		}
+	})
+
+	t.Run("Invalid regex is reported as an error", func(t *testing.T) {
+		result := types.That().
+			ResideInNamespace(goarchtest.RegexNamespace("(")).
+			ShouldNot().
+			HaveDependencyOn("infrastructure").
+			GetResult()
+
+		if result.IsSuccessful || result.Err == nil {
+			t.Error("Expected an invalid pattern to fail the rule with an error")
+		}
+	})
+
+	t.Run("DoNotResideInNamespace matches whole segments of the import path", func(t *testing.T) {
+		for path := range packagesOf(types.That().DoNotResideInNamespace("user").GetAllTypes()) {
+			if strings.Contains(path, "/internal/user/") {
+				t.Errorf("Expected %s to be excluded by DoNotResideInNamespace(\"user\")", path)
+			}
+		}
+
+		// "use" is not a segment of any path, so nothing is excluded
+		if got, all := types.That().DoNotResideInNamespace("use").GetAllTypes(), types.That().GetAllTypes(); len(got) != len(all) {
+			t.Errorf("Expected partial segment names not to exclude anything, got %d of %d types", len(got), len(all))
+		}
+	})
+}
+
+// TestDDDArchitectureOptions tests the options-based DDD pattern constructor
+func TestDDDArchitectureOptions(t *testing.T) {
+	projectPath, err := filepath.Abs("./")
+	if err != nil {
+		t.Fatalf("Failed to get project path: %v", err)
+	}
+
+	types := goarchtest.InPath(projectPath)
+	domains := []string{"user", "order"}
+
+	ruleIDs := func(pattern *goarchtest.ArchitecturePattern) map[string]bool {
+		ids := make(map[string]bool)
+		for _, result := range pattern.Validate(types) {
+			ids[result.RuleID] = true
+			if !result.IsSuccessful {
+				t.Errorf("Rule %s failed: %s", result.RuleID, result.RuleDescription)
+			}
+		}
+		return ids
+	}
+
+	t.Run("Shared kernel and pkg rules are enforced", func(t *testing.T) {
+		ids := ruleIDs(goarchtest.DDDWithCleanArchitecture(domains, "shared", "pkg"))
+
+		for _, expected := range []string{
+			"ddd/user-domain-not-depend-on-application",
+			"ddd/shared-kernel-not-depend-on-user",
+			"ddd/shared-kernel-not-depend-on-order",
+			"ddd/pkg-not-depend-on-internal",
+		} {
+			if !ids[expected] {
+				t.Errorf("Expected rule %s, got %v", expected, ids)
+			}
+		}
+	})
+
+	t.Run("Pkg rule selects only public packages", func(t *testing.T) {
+		// internal/user/pkg imports the user domain, which is not a public package
+		for _, result := range goarchtest.DDDWithCleanArchitecture(domains, "shared", "pkg").Validate(types) {
+			if result.RuleID == "ddd/pkg-not-depend-on-internal" && !result.IsSuccessful {
+				t.Errorf("Expected internal/user/pkg not to be checked as a public package, got %v", result.FailingTypes)
+			}
+		}
+
+		sources, err := goarchtest.FromSources(map[string]string{
+			"internal/user/domain/user.go": "package domain\n\ntype User struct{}\n",
+			"internal/user/pkg/format.go":  "package pkg\n\nimport \"app/internal/user/domain\"\n\ntype Formatter struct{ user domain.User }\n",
+			"pkg/client/client.go":         "package client\n\nimport \"app/internal/user/domain\"\n\ntype Client struct{ user domain.User }\n",
+		})
+		if err != nil {
+			t.Fatalf("Failed to load sources: %v", err)
+		}
+		for _, result := range goarchtest.DDDWithCleanArchitecture(domains, "shared", "pkg").Validate(sources) {
+			if result.RuleID != "ddd/pkg-not-depend-on-internal" {
+				continue
+			}
+			if len(result.FailingTypes) != 1 || result.FailingTypes[0].Name != "Client" {
+				t.Errorf("Expected only the public client to be reported, got %v", result.FailingTypes)
+			}
+		}
+	})
+
+	t.Run("Extra layers add inward dependency rules", func(t *testing.T) {
+		pattern := goarchtest.DDDArchitecture(domains,
+			goarchtest.WithDDDLayers("domain/models", "domain/ports", "application", "infrastructure"),
+		)
+		ids := ruleIDs(pattern)
+
+		if len(pattern.Rules) != 17 {
+			t.Errorf("Expected 12 layer, 2 isolation, 2 shared kernel and 1 pkg rules, got %d", len(pattern.Rules))
+		}
+		if !ids["ddd/order-domain-models-not-depend-on-domain-ports"] {
+			t.Errorf("Expected a rule between the models and ports layers, got %v", ids)
+		}
+	})
+
+	t.Run("Root and shared kernel are configurable", func(t *testing.T) {
+		pattern := goarchtest.DDDArchitecture(domains,
+			goarchtest.WithDDDRoot("contexts"),
+			goarchtest.WithDDDSharedKernel(""),
+		)
+
+		for _, rule := range pattern.Rules {
+			if strings.Contains(rule.ID, "shared-kernel") {
+				t.Errorf("Expected no shared kernel rules when disabled, got %s", rule.ID)
+			}
+		}
+		if !strings.Contains(pattern.Rules[0].Description, "contexts/user/domain") {
+			t.Errorf("Expected layer namespaces under the root, got %q", pattern.Rules[0].Description)
+		}
+		if got := pattern.Rules[len(pattern.Rules)-1].ID; got != "ddd/pkg-not-depend-on-contexts" {
+			t.Errorf("Expected pkg not to depend on the custom root, got %s", got)
+		}
+	})
+}
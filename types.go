@@ -1,10 +1,13 @@
 package goarchtest
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"os"
+	"sort"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
@@ -12,8 +15,28 @@ import (
 
 // Types represents the entry point for architecture testing
 type Types struct {
-	pkgs    []*packages.Package
-	typeSet *TypeSet
+	pkgs      []*packages.Package
+	packages  map[string]*packages.Package
+	typeSet   *TypeSet
+	importers map[string][]string
+	index     *modelIndex
+	config    *config
+	cache     CacheStats
+
+	// typeInfo is set when the packages were type-checked
+	typeInfo bool
+
+	// loadErrors holds the package errors of models loaded without type
+	// information, which have no loaded packages
+	loadErrors []error
+
+	// path and opts are the arguments the model was loaded with, or sources
+	// for models loaded by FromSources, so that it can be reloaded;
+	// sharedKey is set for models memoized by Shared
+	path      string
+	opts      []Option
+	sources   map[string]string
+	sharedKey string
 }
 
 // TypeSet represents a collection of types that match certain criteria
@@ -22,6 +45,39 @@ type TypeSet struct {
 	originalTypes     []*TypeInfo
 	currentPredicate  string
 	matchedPredicates []string
+	model             *Types
+
+	// matchEvidence explains why a type matched a predicate; it is reported
+	// when the chain is negated. missEvidence explains why a type was dropped
+	// by a condition; it is reported for positive chains.
+	matchEvidence map[*TypeInfo][]Violation
+	missEvidence  map[*TypeInfo][]Violation
+
+	// strict is set by conditions that every selected type must satisfy,
+	// as opposed to filters where a single match is enough.
+	strict bool
+
+	// err holds configuration errors, such as invalid patterns, that make
+	// the chain impossible to evaluate; typeInfoRequired is set once a
+	// predicate asked for type information the model was loaded without
+	err              error
+	typeInfoRequired bool
+
+	// conditioned is set once Should or ShouldNot splits the chain into a
+	// selection and a condition; selected holds the selection at that point
+	conditioned bool
+	selected    []*TypeInfo
+
+	// exemptions lists types excluded from the selection; exceptions are
+	// exclusions on the condition side, applied when the result is evaluated
+	exemptions []Exemption
+	exceptions []exception
+
+	// phrases build the human-readable description of the chain;
+	// joinable is set when the last phrase was a predicate, so that the
+	// next predicate is joined with "and"
+	phrases  []string
+	joinable bool
 }
 
 // TypeInfo contains comprehensive information about a Go type.
@@ -37,6 +93,7 @@ type TypeSet struct {
 //   - Interfaces: For interface types, the method names defined in the interface
 //   - IsStruct: true if this type is a struct
 //   - IsInterface: true if this type is an interface
+//   - Position: Where the type is declared
 //
 // TypeInfo is used throughout GoArchTest's predicate system to make architectural
 // decisions and validate constraints.
@@ -48,6 +105,12 @@ type TypeInfo struct {
 	Interfaces  []string
 	IsStruct    bool
 	IsInterface bool
+	Position    Position
+
+	// id identifies the type within its model, for set operations;
+	// importPositions locates the imports of the package, shared by its types
+	id              int
+	importPositions map[string][]Position
 }
 
 // InPath creates a new Types instance for packages in the specified directory path.
@@ -57,6 +120,7 @@ type TypeInfo struct {
 //
 // Parameters:
 //   - path: The directory path to analyze. Use "." for current directory or provide an absolute path.
+//   - opts: Optional settings such as WithStrictMode or WithCache.
 //
 // Returns:
 //   - *Types: A Types instance containing all discovered types, ready for filtering and testing.
@@ -78,104 +142,222 @@ type TypeInfo struct {
 //
 // The function uses Go's package loading mechanism to extract comprehensive
 // type information including names, packages, imports, and structural details.
-func InPath(path string) *Types {
+// Loading errors are printed and yield an empty model; use LoadContext to
+// handle them, to cancel loading or to observe its progress.
+func InPath(path string, opts ...Option) *Types {
+	types, err := LoadContext(context.Background(), path, opts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load packages: %v\n", err)
+		types = emptyTypes(newConfig(opts))
+		types.path, types.opts = path, opts
+	}
+	return types
+}
+
+// loadTypes loads the model of the packages in path
+func loadTypes(ctx context.Context, path string, options *config) (*Types, error) {
+	if options.cache && options.loadMode != LoadImports {
+		return nil, ErrCacheRequiresLoadImports
+	}
+	if options.loadMode == LoadImports {
+		return loadImportsOnly(ctx, path, options)
+	}
+
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedImports,
-		Dir:  path,
+		Mode:       packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedImports,
+		Context:    ctx,
+		Dir:        path,
+		BuildFlags: options.buildFlags,
+		Overlay:    options.overlay,
 	}
 
 	pkgs, err := packages.Load(cfg, "./...")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to load packages: %v\n", err)
-		return &Types{
-			pkgs:    []*packages.Package{},
-			typeSet: &TypeSet{types: []*TypeInfo{}, originalTypes: []*TypeInfo{}},
-		}
+		return nil, err
 	}
+	options.reportProgress(Progress{Loaded: len(pkgs)})
+
+	typeSet := extractTypesFromPackages(pkgs, options)
+	return &Types{
+		pkgs:      pkgs,
+		packages:  indexPackages(pkgs),
+		typeSet:   typeSet,
+		importers: buildImporterIndex(pkgs),
+		index:     newModelIndex(typeSet.types),
+		config:    options,
+		typeInfo:  true,
+	}, nil
+}
 
+// emptyTypes returns the model of packages that failed to load
+func emptyTypes(options *config) *Types {
 	return &Types{
-		pkgs:    pkgs,
-		typeSet: extractTypesFromPackages(pkgs),
+		pkgs:      []*packages.Package{},
+		packages:  map[string]*packages.Package{},
+		typeSet:   &TypeSet{types: []*TypeInfo{}, originalTypes: []*TypeInfo{}},
+		importers: map[string][]string{},
+		config:    options,
 	}
 }
 
-// That starts a filter chain to select types
+// That starts a filter chain to select types.
+// Every call returns a fresh TypeSet, so chains never affect each other.
 func (t *Types) That() *TypeSet {
-	return t.typeSet.That()
+	all := t.typeSet.types
+	ts := &TypeSet{
+		types:         all[:len(all):len(all)],
+		originalTypes: all[:len(all):len(all)],
+		model:         t,
+	}
+	return ts.That()
+}
+
+// ImportersOf returns the import paths of the loaded packages that import
+// the package with the given import path, sorted alphabetically.
+//
+// The index is built once when the packages are loaded, so the call is cheap.
+// Only packages that were part of the analysis are reported; importers outside
+// the analyzed path are unknown.
+//
+// Example:
+//
+//	for _, importer := range types.ImportersOf("github.com/myorg/app/infrastructure/db") {
+//	    fmt.Println(importer)
+//	}
+func (t *Types) ImportersOf(pkgPath string) []string {
+	return append([]string(nil), t.importers[pkgPath]...)
+}
+
+// indexPackages maps the import path of each loaded package to the package
+func indexPackages(pkgs []*packages.Package) map[string]*packages.Package {
+	index := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		index[pkg.PkgPath] = pkg
+	}
+	return index
+}
+
+// buildImporterIndex builds the reverse-dependency index, mapping each import
+// path to the loaded packages that import it
+func buildImporterIndex(pkgs []*packages.Package) map[string][]string {
+	importers := make(map[string][]string)
+	for _, pkg := range pkgs {
+		for importPath := range pkg.Imports {
+			importers[importPath] = append(importers[importPath], pkg.PkgPath)
+		}
+	}
+
+	for _, list := range importers {
+		sort.Strings(list)
+	}
+
+	return importers
 }
 
 // extractTypesFromPackages processes the packages to extract type information
-func extractTypesFromPackages(pkgs []*packages.Package) *TypeSet {
+func extractTypesFromPackages(pkgs []*packages.Package, options *config) *TypeSet {
 	var types []*TypeInfo
 
-	for _, pkg := range pkgs {
+	// Import paths are interned, so that the paths shared by many packages are stored once
+	interned := make(map[string]string)
+
+	for i, pkg := range pkgs {
 		// Skip packages with errors
-		if len(pkg.Errors) > 0 {
-			continue
+		if len(pkg.Errors) == 0 {
+			types = append(types, extractTypes(pkg, pkg.Fset, packageImports(pkg, interned), pkg.Syntax)...)
 		}
+		options.reportProgress(Progress{Loaded: len(pkgs), Analyzed: i + 1})
+	}
 
-		imports := make([]string, 0)
-		for importPath := range pkg.Imports {
-			imports = append(imports, importPath)
+	numberTypes(types)
+	return &TypeSet{
+		types:         types,
+		originalTypes: types,
+	}
+}
+
+// packageImports returns the sorted import paths of a package, interned
+// through the given map
+func packageImports(pkg *packages.Package, interned map[string]string) []string {
+	imports := make([]string, 0, len(pkg.Imports))
+	for importPath := range pkg.Imports {
+		if existing, ok := interned[importPath]; ok {
+			importPath = existing
+		} else {
+			interned[importPath] = importPath
 		}
+		imports = append(imports, importPath)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// extractTypes collects the types declared in the syntax trees of a package.
+// Syntax trees are used since we can't easily map from types.Object to
+// struct/interface information.
+func extractTypes(pkg *packages.Package, fset *token.FileSet, imports []string, files []*ast.File) []*TypeInfo {
+	var types []*TypeInfo
+	positions := importPositions(fset, files)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
 
-		// Get types from this package using syntax trees since we can't easily
-		// map from types.Object to struct/interface information
-		for _, file := range pkg.Syntax {
-			for _, decl := range file.Decls {
-				genDecl, ok := decl.(*ast.GenDecl)
-				if !ok || genDecl.Tok != token.TYPE {
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
 					continue
 				}
 
-				for _, spec := range genDecl.Specs {
-					typeSpec, ok := spec.(*ast.TypeSpec)
-					if !ok {
-						continue
-					}
-
-					typeInfo := &TypeInfo{
-						Name:     typeSpec.Name.Name,
-						Package:  pkg.Name,
-						FullPath: pkg.PkgPath,
-						Imports:  imports,
-					}
+				typeInfo := &TypeInfo{
+					Name:            typeSpec.Name.Name,
+					Package:         pkg.Name,
+					FullPath:        pkg.PkgPath,
+					Imports:         imports,
+					Position:        newPosition(fset, typeSpec.Name.Pos()),
+					importPositions: positions,
+				}
 
-					// Check if it's a struct
-					if _, ok := typeSpec.Type.(*ast.StructType); ok {
-						typeInfo.IsStruct = true
-					}
+				// Check if it's a struct
+				if _, ok := typeSpec.Type.(*ast.StructType); ok {
+					typeInfo.IsStruct = true
+				}
 
-					// Check if it's an interface
-					if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
-						typeInfo.IsInterface = true
-						// Collect method names from the interface
-						if interfaceType.Methods != nil {
-							for _, method := range interfaceType.Methods.List {
-								if method.Names != nil {
-									for _, name := range method.Names {
-										typeInfo.Interfaces = append(typeInfo.Interfaces, name.Name)
-									}
-								}
+				// Check if it's an interface
+				if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+					typeInfo.IsInterface = true
+					// Collect method names from the interface
+					if interfaceType.Methods != nil {
+						for _, method := range interfaceType.Methods.List {
+							for _, name := range method.Names {
+								typeInfo.Interfaces = append(typeInfo.Interfaces, name.Name)
 							}
 						}
 					}
-
-					types = append(types, typeInfo)
 				}
+
+				types = append(types, typeInfo)
 			}
 		}
 	}
+	return types
+}
 
-	return &TypeSet{
-		types:         types,
-		originalTypes: types,
+// numberTypes assigns the model identifiers of the types, in order
+func numberTypes(types []*TypeInfo) {
+	for i, t := range types {
+		t.id = i + 1
 	}
 }
 
 // That starts a filter chain
 func (ts *TypeSet) That() *TypeSet {
 	ts.currentPredicate = "That"
+	if len(ts.phrases) == 0 {
+		ts.connect("types that")
+	}
 	return ts
 }
 
@@ -187,6 +369,17 @@ func (ts *TypeSet) That() *TypeSet {
 // Fields:
 //   - IsSuccessful: true if the architectural test passed, false otherwise
 //   - FailingTypes: slice of TypeInfo for types that didn't meet the criteria
+//   - Violations: one entry per reason a failing type broke the rule, e.g. each
+//     unauthorized importer reported by OnlyBeAccessedBy
+//   - Err: set when the rule itself is invalid, e.g. a malformed namespace pattern;
+//     such a rule never succeeds
+//   - SelectedCount: number of types selected before Should or ShouldNot
+//   - EvaluatedCount: number of types the condition was evaluated against
+//   - Warnings: notes that do not necessarily fail the rule, such as an empty selection
+//   - Exemptions: types excluded by Except, ExceptTypes, ExceptNamespaces or ExceptWhere,
+//     each with its documented reason
+//   - Description: a sentence generated from the chain, e.g.
+//     "types that reside in namespace 'domain' should not have dependency on 'infrastructure'"
 //
 // Example usage:
 //
@@ -198,8 +391,31 @@ func (ts *TypeSet) That() *TypeSet {
 //	    }
 //	}
 type Result struct {
+	Description  string
 	IsSuccessful bool
 	FailingTypes []*TypeInfo
+	Violations   []Violation
+	Exemptions   []Exemption
+	Err          error
+
+	SelectedCount  int
+	EvaluatedCount int
+	Warnings       []string
+}
+
+// Violation describes a single reason why a type broke an architectural rule.
+//
+// Fields:
+//   - Type: The offending type
+//   - Reason: A human-readable explanation, empty when the rule gave none
+//   - Evidence: The concrete item that caused the violation, such as an import path
+//   - Position: Where the violation occurs, such as the offending import, or else
+//     the declaration of the type
+type Violation struct {
+	Type     *TypeInfo
+	Reason   string
+	Evidence string
+	Position Position
 }
 
 // GetResult evaluates the predicates and returns the result
@@ -207,10 +423,20 @@ func (ts *TypeSet) GetResult() *Result {
 	// If no predicates were applied, the test passes
 	if len(ts.matchedPredicates) == 0 {
 		return &Result{
+			Description:  ts.Description(),
 			IsSuccessful: true,
 		}
 	}
 
+	// An invalid rule cannot be evaluated
+	if ts.err != nil {
+		return &Result{
+			Description:  ts.Description(),
+			IsSuccessful: false,
+			Err:          ts.err,
+		}
+	}
+
 	// Check if we have a negation in the predicates
 	shouldNegate := false
 	for _, pred := range ts.matchedPredicates {
@@ -220,19 +446,189 @@ func (ts *TypeSet) GetResult() *Result {
 		}
 	}
 
-	// If we're negating, the result is successful if we have NO matching types
+	var result *Result
 	if shouldNegate {
-		return &Result{
-			IsSuccessful: len(ts.types) == 0,
-			FailingTypes: ts.types, // If we're negating, the failing types are the ones that matched
+		// If we're negating, the result is successful if we have NO matching types
+		failingTypes, exempted := ts.exempt(ts.types) // If we're negating, the failing types are the ones that matched
+		result = &Result{
+			IsSuccessful: len(failingTypes) == 0,
+			FailingTypes: failingTypes,
+			Violations:   collectViolations(failingTypes, ts.matchEvidence),
+			Exemptions:   append(append([]Exemption{}, ts.exemptions...), exempted...),
 		}
+	} else {
+		failingTypes, exempted := ts.exempt(ts.getFailingTypes())
+
+		// Strict conditions must hold for every selected type; otherwise the
+		// result is successful if we have matching types, or if every
+		// failing type was exempted
+		isSuccessful := len(ts.types) > 0 || (len(exempted) > 0 && len(failingTypes) == 0)
+		if ts.strict {
+			isSuccessful = len(failingTypes) == 0
+		}
+
+		result = &Result{
+			IsSuccessful: isSuccessful,
+			FailingTypes: failingTypes,
+			Violations:   collectViolations(failingTypes, ts.missEvidence),
+			Exemptions:   append(append([]Exemption{}, ts.exemptions...), exempted...),
+		}
+	}
+
+	result.Description = ts.Description()
+	if ts.conditioned {
+		result.SelectedCount = len(ts.selected)
+		result.EvaluatedCount = len(ts.selected) - ts.exemptedCount()
+		ts.checkEmptySelection(result)
+	}
+
+	return result
+}
+
+// checkEmptySelection applies the empty selection policy to a vacuous rule
+func (ts *TypeSet) checkEmptySelection(result *Result) {
+	if len(ts.selected) > 0 || ts.model == nil {
+		return
+	}
+
+	switch ts.model.config.emptySelectionPolicy() {
+	case EmptySelectionWarn:
+		result.Warnings = append(result.Warnings, "rule selected no types, so it passes vacuously")
+	case EmptySelectionFail:
+		result.IsSuccessful = false
+		result.Warnings = append(result.Warnings, "rule selected no types; check that the selection still matches the codebase")
+	}
+}
+
+// collectViolations returns the recorded evidence for each failing type, or a
+// bare violation when no predicate explained the failure
+func collectViolations(failingTypes []*TypeInfo, evidence map[*TypeInfo][]Violation) []Violation {
+	var violations []Violation
+	for _, t := range failingTypes {
+		recorded := evidence[t]
+		if len(recorded) == 0 {
+			recorded = []Violation{{Type: t}}
+		}
+
+		for _, v := range recorded {
+			if !v.Position.IsValid() {
+				v.Position = t.Position
+			}
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// violationReasons returns the reasons recorded for a type among the violations
+func violationReasons(t *TypeInfo, violations []Violation) []string {
+	var reasons []string
+	for _, v := range violations {
+		if v.Type == t && v.Reason != "" {
+			reasons = append(reasons, v.Reason)
+		}
+	}
+	return reasons
+}
+
+// derive returns a copy of the TypeSet holding the given types, so that the
+// receiver is left untouched
+func (ts *TypeSet) derive(types []*TypeInfo) *TypeSet {
+	return &TypeSet{
+		types:             types,
+		originalTypes:     ts.originalTypes, // Keep reference to original types
+		currentPredicate:  ts.currentPredicate,
+		matchedPredicates: append([]string{}, ts.matchedPredicates...), // Copy slice
+		model:             ts.model,
+		matchEvidence:     copyEvidence(ts.matchEvidence),
+		missEvidence:      copyEvidence(ts.missEvidence),
+		strict:            ts.strict,
+		err:               ts.err,
+		typeInfoRequired:  ts.typeInfoRequired,
+		conditioned:       ts.conditioned,
+		selected:          ts.selected,
+		exemptions:        append([]Exemption{}, ts.exemptions...), // Copy slice
+		exceptions:        append([]exception{}, ts.exceptions...), // Copy slice
+		phrases:           append([]string{}, ts.phrases...),       // Copy slice
+		joinable:          ts.joinable,
 	}
+}
+
+// describe appends a predicate phrase to the description of the chain.
+// Phrases use the verb form that follows "should"; before Should or ShouldNot
+// a leading "be" is turned into "are", e.g. "types that are structs".
+func (ts *TypeSet) describe(phrase string) {
+	if !ts.conditioned && strings.HasPrefix(phrase, "be ") {
+		phrase = "are " + strings.TrimPrefix(phrase, "be ")
+	}
+	if ts.joinable {
+		ts.phrases = append(ts.phrases, "and")
+	}
+	ts.phrases = append(ts.phrases, phrase)
+	ts.joinable = true
+}
+
+// connect appends a connector such as "and" or "should" to the description
+func (ts *TypeSet) connect(connector string) {
+	ts.phrases = append(ts.phrases, connector)
+	ts.joinable = false
+}
+
+// Description returns a human-readable sentence describing the chain built so far,
+// e.g. "types that reside in namespace 'domain' should not have dependency on 'infrastructure'"
+func (ts *TypeSet) Description() string {
+	return strings.Join(ts.phrases, " ")
+}
+
+// quoteAll quotes each value and joins them with commas, for descriptions
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// recordError stores a configuration error, keeping all errors of the chain
+func (ts *TypeSet) recordError(err error) {
+	ts.err = errors.Join(ts.err, err)
+}
+
+// requireTypeInfo records, once per chain, that the current predicate needs
+// type information the model was loaded without
+func (ts *TypeSet) requireTypeInfo() {
+	if ts.typeInfoRequired {
+		return
+	}
+	ts.typeInfoRequired = true
+	ts.recordError(fmt.Errorf("predicate %q: %w; load the model with LoadFull", ts.currentPredicate, ErrTypeInfoUnavailable))
+}
 
-	// Otherwise, the result is successful if we have matching types
-	return &Result{
-		IsSuccessful: len(ts.types) > 0,
-		FailingTypes: ts.getFailingTypes(),
+// recordMatch stores why a type matched the current predicate
+func (ts *TypeSet) recordMatch(v Violation) {
+	if ts.matchEvidence == nil {
+		ts.matchEvidence = make(map[*TypeInfo][]Violation)
 	}
+	ts.matchEvidence[v.Type] = append(ts.matchEvidence[v.Type], v)
+}
+
+// recordMiss stores why a type failed the current condition
+func (ts *TypeSet) recordMiss(v Violation) {
+	if ts.missEvidence == nil {
+		ts.missEvidence = make(map[*TypeInfo][]Violation)
+	}
+	ts.missEvidence[v.Type] = append(ts.missEvidence[v.Type], v)
+}
+
+func copyEvidence(evidence map[*TypeInfo][]Violation) map[*TypeInfo][]Violation {
+	if evidence == nil {
+		return nil
+	}
+	copied := make(map[*TypeInfo][]Violation, len(evidence))
+	for t, violations := range evidence {
+		copied[t] = append([]Violation(nil), violations...)
+	}
+	return copied
 }
 
 // getFailingTypes returns types that didn't match the predicates
@@ -240,16 +636,9 @@ func (ts *TypeSet) getFailingTypes() []*TypeInfo {
 	var failingTypes []*TypeInfo
 
 	// Compare original types with the filtered ones
+	filtered := newTypeIDs(ts.types)
 	for _, origType := range ts.originalTypes {
-		found := false
-		for _, filteredType := range ts.types {
-			if origType.FullPath == filteredType.FullPath && origType.Name == filteredType.Name {
-				found = true
-				break
-			}
-		}
-
-		if !found {
+		if !filtered.contains(origType) {
 			failingTypes = append(failingTypes, origType)
 		}
 	}
@@ -268,11 +657,25 @@ func (r *Result) GetFailureDetails() string {
 		return "No failures detected"
 	}
 
+	if r.Err != nil {
+		return fmt.Sprintf("Invalid rule %q: %v\n", r.Description, r.Err)
+	}
+
 	var details strings.Builder
+	if r.Description != "" {
+		details.WriteString(fmt.Sprintf("Rule: %s\n", r.Description))
+	}
 	details.WriteString(fmt.Sprintf("Found %d failing type(s):\n", len(r.FailingTypes)))
 
 	for i, failingType := range r.FailingTypes {
 		details.WriteString(fmt.Sprintf("%d. %s in package %s\n", i+1, failingType.Name, failingType.Package))
+		for _, reason := range violationReasons(failingType, r.Violations) {
+			details.WriteString(fmt.Sprintf("   %s\n", reason))
+		}
+	}
+
+	for _, exemption := range r.Exemptions {
+		details.WriteString(fmt.Sprintf("Exempted: %s in package %s (%s)\n", exemption.Type.Name, exemption.Type.Package, exemption.Reason))
 	}
 
 	return details.String()